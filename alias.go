@@ -0,0 +1,63 @@
+package m2s
+
+import (
+	"maps"
+	"mime/multipart"
+)
+
+// resolveAliases returns a copy of mpf in which the keys of fields that
+// were sent under an alias are also available under the key of the field.
+// The key of a field takes priority over its aliases, and aliases over
+// the ones listed after them; only the first key that is present is used.
+func (c *config) resolveAliases(mpf *multipart.Form, fields []field) *multipart.Form {
+	resolved := &multipart.Form{
+		Value: maps.Clone(mpf.Value),
+		File:  maps.Clone(mpf.File),
+	}
+	for _, f := range fields {
+		if len(f.aliases) == 0 || isRemaining(f) {
+			continue
+		}
+		var alias string
+		if f.kind == FieldValue {
+			alias = resolveAlias(resolved.Value, f)
+		} else {
+			alias = resolveAlias(resolved.File, f)
+		}
+		if alias != "" && c.reportAlias != nil {
+			c.reportAlias(f.key, alias)
+		}
+	}
+	return resolved
+}
+
+// resolveAlias copies the entries of the first alias of f that is present
+// in m to the key of f, unless the key itself is present. It returns the
+// alias used, if any.
+func resolveAlias[V any](m map[string][]V, f field) string {
+	array := isArrayType(f.withoutOptional().typ)
+	present := func(name string) []string {
+		var keys []string
+		for key := range m {
+			if matchesKey(name, key, array) {
+				keys = append(keys, key)
+			}
+		}
+		return keys
+	}
+
+	if len(present(f.key)) > 0 {
+		return ""
+	}
+	for _, alias := range f.aliases {
+		keys := present(alias)
+		if len(keys) == 0 {
+			continue
+		}
+		for _, key := range keys {
+			m[f.key+key[len(alias):]] = m[key]
+		}
+		return alias
+	}
+	return ""
+}
@@ -0,0 +1,20 @@
+// Command m2svet checks form struct tags used by m2s.
+//
+// It can be run standalone:
+//
+//	m2svet ./...
+//
+// or through go vet:
+//
+//	go vet -vettool=$(which m2svet) ./...
+package main
+
+import (
+	"golang.org/x/tools/go/analysis/singlechecker"
+
+	"github.com/ksckaan1/m2s/analysis/formtag"
+)
+
+func main() {
+	singlechecker.Main(formtag.Analyzer)
+}
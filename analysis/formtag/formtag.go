@@ -0,0 +1,425 @@
+// Package formtag defines an Analyzer that reports struct fields m2s cannot
+// bind: fields with types Convert cannot decode, two fields mapped to the
+// same form key, invalid form tag options, unknown modifiers and file types
+// that are not bound as files.
+//
+// A struct is checked if one of its fields has a form tag or if it is passed
+// to m2s.Convert or used as the type argument of one of the generic m2s
+// functions.
+package formtag
+
+import (
+	"go/ast"
+	"go/types"
+	"reflect"
+	"slices"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/inspector"
+
+	"github.com/ksckaan1/m2s/internal/tags"
+)
+
+const doc = `check form struct tags used by m2s
+
+The formtag analyzer reports struct fields that m2s cannot bind, which
+would otherwise only fail at runtime: fields whose type cannot be decoded
+(chan, func, interface, ...), two fields mapped to the same form key,
+tag options that are malformed or do not apply to the field, modifiers
+that are not registered, and file types such as multipart.File or
+[]**multipart.FileHeader that m2s does not bind as files.
+
+The -tag, -json and -mods flags match the m2s.WithTagName,
+m2s.WithJSONFallback and m2s.WithModifier options.`
+
+const m2sPath = "github.com/ksckaan1/m2s"
+
+var Analyzer = &analysis.Analyzer{
+	Name:     "formtag",
+	Doc:      doc,
+	URL:      "https://pkg.go.dev/github.com/ksckaan1/m2s/analysis/formtag",
+	Requires: []*analysis.Analyzer{inspect.Analyzer},
+	Run:      run,
+}
+
+// Flags mirror the m2s.WithTagName, m2s.WithJSONFallback and
+// m2s.WithModifier options.
+var (
+	tagName         string
+	jsonFallback    bool
+	customModifiers []string
+)
+
+func init() {
+	Analyzer.Flags.StringVar(&tagName, "tag", "form", "name of the struct tag m2s reads")
+	Analyzer.Flags.BoolVar(&jsonFallback, "json", false, "fall back to json tags for fields without a tag")
+	Analyzer.Flags.Func("mods", "comma-separated modifiers registered with m2s.WithModifier", func(s string) error {
+		customModifiers = nil
+		if s != "" {
+			customModifiers = strings.Split(s, ",")
+		}
+		return nil
+	})
+}
+
+func run(pass *analysis.Pass) (any, error) {
+	ins := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
+
+	bound := boundStructs(pass, ins)
+
+	ins.Preorder([]ast.Node{(*ast.StructType)(nil)}, func(n ast.Node) {
+		st := n.(*ast.StructType)
+		t, ok := pass.TypesInfo.Types[st].Type.(*types.Struct)
+		if !ok {
+			return
+		}
+		if !hasFormTag(t) && !bound[t] {
+			return
+		}
+		checkStruct(pass, st, t)
+	})
+
+	return nil, nil
+}
+
+// boundStructs returns the struct types passed to m2s.Convert or used as
+// type arguments of the generic m2s functions.
+func boundStructs(pass *analysis.Pass, ins *inspector.Inspector) map[*types.Struct]bool {
+	bound := make(map[*types.Struct]bool)
+
+	add := func(t types.Type) {
+		if p, ok := t.Underlying().(*types.Pointer); ok {
+			t = p.Elem()
+		}
+		if s, ok := t.Underlying().(*types.Struct); ok {
+			bound[s] = true
+		}
+	}
+
+	ins.Preorder([]ast.Node{(*ast.CallExpr)(nil)}, func(n ast.Node) {
+		call := n.(*ast.CallExpr)
+
+		fun := ast.Unparen(call.Fun)
+		if ix, ok := fun.(*ast.IndexExpr); ok {
+			fun = ix.X
+		}
+		sel, ok := fun.(*ast.SelectorExpr)
+		if !ok {
+			return
+		}
+		fn, ok := pass.TypesInfo.Uses[sel.Sel].(*types.Func)
+		if !ok || fn.Pkg() == nil || fn.Pkg().Path() != m2sPath {
+			return
+		}
+
+		if fn.Name() == "Convert" && len(call.Args) >= 2 {
+			add(pass.TypesInfo.TypeOf(call.Args[1]))
+			return
+		}
+
+		inst, ok := pass.TypesInfo.Instances[sel.Sel]
+		if !ok {
+			return
+		}
+		for i := range inst.TypeArgs.Len() {
+			add(inst.TypeArgs.At(i))
+		}
+	})
+
+	return bound
+}
+
+func hasFormTag(t *types.Struct) bool {
+	for i := range t.NumFields() {
+		if _, ok := reflect.StructTag(t.Tag(i)).Lookup(tagName); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func checkStruct(pass *analysis.Pass, st *ast.StructType, t *types.Struct) {
+	keys := make(map[string]string)
+
+	for i := range t.NumFields() {
+		v := t.Field(i)
+		tag := tags.Get(reflect.StructTag(t.Tag(i)), tagName, jsonFallback)
+		if !v.Exported() || tag == "-" {
+			continue
+		}
+
+		pos := fieldNode(st, v)
+
+		name, opts := tags.Parse(tag)
+		for _, err := range tags.Check(opts, tagType(v.Type())) {
+			pass.ReportRangef(pos, "field %s has an invalid %s tag: %v", v.Name(), tagName, err)
+		}
+		checkModifiers(pass, pos, v, reflect.StructTag(t.Tag(i)).Get("mod"))
+
+		key, aliases := tags.Keys(name, opts)
+		if key == "" {
+			key = v.Name()
+		}
+		for _, key := range append([]string{key}, aliases...) {
+			if other, ok := keys[key]; ok {
+				pass.ReportRangef(pos, "field %s uses form key %q, which is already used by field %s", v.Name(), key, other)
+			} else {
+				keys[key] = v.Name()
+			}
+		}
+
+		if opts.Contains("remaining") {
+			continue // checked by tags.Check
+		}
+
+		if determineFieldType(v.Type()) != value {
+			continue
+		}
+
+		if containsFileType(v.Type()) {
+			pass.ReportRangef(pos, "field %s has type %s, which is not bound as a file; use *multipart.FileHeader or []*multipart.FileHeader",
+				v.Name(), typeString(pass, v.Type()))
+			continue
+		}
+
+		if !canDecode(v.Type()) {
+			pass.ReportRangef(pos, "field %s has type %s, which m2s cannot decode",
+				v.Name(), typeString(pass, v.Type()))
+		}
+	}
+}
+
+// tagType mirrors tagType in m2s.go for go/types.
+func tagType(t types.Type) tags.Type {
+	t = withoutOptional(t)
+	number := numberKind(t)
+	return tags.Type{
+		Time:      isNamed(indirect(t), "time", "Time"),
+		Bool:      isBool(t),
+		Duration:  isDuration(t),
+		List:      isList(t) && determineFieldType(t) == value,
+		Integer:   number&types.IsInteger != 0,
+		Float:     number&types.IsFloat != 0,
+		Remaining: isRemainingType(t),
+	}
+}
+
+// checkModifiers reports the modifiers in the mod tag of v that are
+// neither built in nor listed in the -mods flag.
+func checkModifiers(pass *analysis.Pass, pos ast.Node, v *types.Var, tag string) {
+	if tag == "" {
+		return
+	}
+	for _, name := range strings.Split(tag, ",") {
+		if !slices.Contains(tags.Modifiers, name) && !slices.Contains(customModifiers, name) {
+			pass.ReportRangef(pos, "field %s has unknown modifier %q", v.Name(), name)
+		}
+	}
+}
+
+// withoutOptional returns T if t is m2s.Optional[T], and t otherwise.
+func withoutOptional(t types.Type) types.Type {
+	n, ok := types.Unalias(t).(*types.Named)
+	if !ok || !isNamed(n.Origin(), m2sPath, "Optional") || n.TypeArgs().Len() != 1 {
+		return t
+	}
+	return n.TypeArgs().At(0)
+}
+
+// isRemainingType mirrors remainingElem in remaining.go for go/types.
+func isRemainingType(t types.Type) bool {
+	m, ok := t.Underlying().(*types.Map)
+	if !ok {
+		return false
+	}
+	if k, ok := m.Key().Underlying().(*types.Basic); !ok || k.Kind() != types.String {
+		return false
+	}
+	s, ok := types.Unalias(m.Elem()).(*types.Slice)
+	if !ok {
+		return false
+	}
+	return types.Identical(s.Elem(), types.Typ[types.String]) || isPointerTo(s.Elem(), isFileHeader)
+}
+
+func isList(t types.Type) bool {
+	switch indirect(t).Underlying().(type) {
+	case *types.Slice, *types.Array:
+		return true
+	default:
+		return false
+	}
+}
+
+func isBool(t types.Type) bool {
+	b, ok := indirect(t).Underlying().(*types.Basic)
+	return ok && b.Info()&types.IsBoolean != 0
+}
+
+// numberKind mirrors isNumberType in number.go for go/types. It returns
+// types.IsInteger or types.IsFloat for numbers, and 0 otherwise.
+func numberKind(t types.Type) types.BasicInfo {
+	t = indirect(t)
+	switch u := t.Underlying().(type) {
+	case *types.Slice:
+		t = indirect(u.Elem())
+	case *types.Array:
+		t = indirect(u.Elem())
+	}
+	if isNamed(t, "time", "Duration") {
+		return 0
+	}
+	b, ok := t.Underlying().(*types.Basic)
+	if !ok {
+		return 0
+	}
+	return b.Info() & (types.IsInteger | types.IsFloat)
+}
+
+// isDuration mirrors isDurationType in duration.go for go/types.
+func isDuration(t types.Type) bool {
+	t = indirect(t)
+	switch u := t.Underlying().(type) {
+	case *types.Slice:
+		t = indirect(u.Elem())
+	case *types.Array:
+		t = indirect(u.Elem())
+	}
+	return isNamed(t, "time", "Duration")
+}
+
+// fieldNode returns the AST node of v in st, so diagnostics point at the
+// field instead of the whole struct.
+func fieldNode(st *ast.StructType, v *types.Var) ast.Node {
+	for _, f := range st.Fields.List {
+		for _, name := range f.Names {
+			if name.Pos() == v.Pos() {
+				return f
+			}
+		}
+		if len(f.Names) == 0 && f.Type.Pos() <= v.Pos() && v.Pos() < f.Type.End() {
+			return f // embedded field
+		}
+	}
+	return st
+}
+
+type fieldType uint
+
+const (
+	value fieldType = iota
+	file
+	files
+)
+
+// determineFieldType mirrors determineFieldType in m2s.go for go/types.
+func determineFieldType(t types.Type) fieldType {
+	if isFileHeader(t) || isPointerTo(t, isFileHeader) {
+		return file
+	}
+	if s, ok := t.Underlying().(*types.Slice); ok && (isFileHeader(s.Elem()) || isPointerTo(s.Elem(), isFileHeader)) {
+		return files
+	}
+	return value
+}
+
+// canDecode mirrors decoderKind in m2s.go for go/types.
+func canDecode(t types.Type) bool {
+	if isTextUnmarshaler(t) {
+		return true
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Pointer:
+		return canDecode(u.Elem())
+	case *types.Basic:
+		info := u.Info()
+		return u.Kind() != types.Uintptr && u.Kind() != types.UnsafePointer &&
+			info&(types.IsString|types.IsInteger|types.IsFloat|types.IsBoolean|types.IsComplex) != 0
+	case *types.Array:
+		return canDecode(u.Elem())
+	case *types.Struct, *types.Slice, *types.Map:
+		return true
+	default:
+		return false
+	}
+}
+
+// containsFileType reports whether t refers to a multipart file type
+// through pointers, slices, arrays or maps.
+func containsFileType(t types.Type) bool {
+	if isFileHeader(t) || isNamed(t, "mime/multipart", "File") || isNamed(t, "os", "File") {
+		return true
+	}
+
+	switch u := t.Underlying().(type) {
+	case *types.Pointer:
+		return containsFileType(u.Elem())
+	case *types.Slice:
+		return containsFileType(u.Elem())
+	case *types.Array:
+		return containsFileType(u.Elem())
+	case *types.Map:
+		return containsFileType(u.Elem())
+	default:
+		return false
+	}
+}
+
+// typeString formats t with package names instead of import paths.
+func typeString(pass *analysis.Pass, t types.Type) string {
+	return types.TypeString(t, func(p *types.Package) string {
+		if p == pass.Pkg {
+			return ""
+		}
+		return p.Name()
+	})
+}
+
+func isTextUnmarshaler(t types.Type) bool {
+	obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(t), false, nil, "UnmarshalText")
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return false
+	}
+	sig := fn.Type().(*types.Signature)
+	if sig.Params().Len() != 1 || sig.Results().Len() != 1 {
+		return false
+	}
+	param, ok := sig.Params().At(0).Type().(*types.Slice)
+	if !ok || !types.Identical(param.Elem(), types.Typ[types.Byte]) {
+		return false
+	}
+	return types.Identical(sig.Results().At(0).Type(), types.Universe.Lookup("error").Type())
+}
+
+// indirect returns the type t points to, following all pointers.
+func indirect(t types.Type) types.Type {
+	for {
+		p, ok := t.Underlying().(*types.Pointer)
+		if !ok {
+			return t
+		}
+		t = p.Elem()
+	}
+}
+
+func isFileHeader(t types.Type) bool {
+	return isNamed(t, "mime/multipart", "FileHeader")
+}
+
+func isPointerTo(t types.Type, pred func(types.Type) bool) bool {
+	p, ok := t.(*types.Pointer)
+	return ok && pred(p.Elem())
+}
+
+func isNamed(t types.Type, pkgPath, name string) bool {
+	n, ok := types.Unalias(t).(*types.Named)
+	if !ok {
+		return false
+	}
+	obj := n.Obj()
+	return obj.Pkg() != nil && obj.Pkg().Path() == pkgPath && obj.Name() == name
+}
@@ -0,0 +1,26 @@
+package formtag_test
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/ksckaan1/m2s/analysis/formtag"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), formtag.Analyzer, "a")
+}
+
+func TestAnalyzerTagFlags(t *testing.T) {
+	formtag.Analyzer.Flags.Set("tag", "m2s")
+	formtag.Analyzer.Flags.Set("json", "true")
+	formtag.Analyzer.Flags.Set("mods", "slugify")
+	t.Cleanup(func() {
+		formtag.Analyzer.Flags.Set("tag", "form")
+		formtag.Analyzer.Flags.Set("json", "false")
+		formtag.Analyzer.Flags.Set("mods", "")
+	})
+
+	analysistest.Run(t, analysistest.TestData(), formtag.Analyzer, "b")
+}
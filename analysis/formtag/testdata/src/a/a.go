@@ -0,0 +1,141 @@
+package a
+
+import (
+	"mime/multipart"
+	"os"
+	"time"
+
+	"github.com/ksckaan1/m2s"
+)
+
+type Valid struct {
+	Name    string                  `form:"name,omitempty"`
+	Age     *int                    `form:"age,redact"`
+	Born    time.Time               `form:"born"`
+	Tags    []string                `form:"tags"`
+	Photo   *multipart.FileHeader   `form:"photo"`
+	Photos  []*multipart.FileHeader `form:"photos"`
+	Ignored func()                  `form:"-"`
+	private chan int
+}
+
+type InvalidTypes struct {
+	Ch  chan int `form:"ch"`     // want `field Ch has type chan int, which m2s cannot decode`
+	Fn  func()   `form:"fn"`     // want `field Fn has type func\(\), which m2s cannot decode`
+	Any any      `form:"any"`    // want `field Any has type any, which m2s cannot decode`
+	Ptr uintptr  `form:"uintpt"` // want `field Ptr has type uintptr, which m2s cannot decode`
+}
+
+type DuplicateKeys struct {
+	Email  string `form:"email"`
+	Email2 string `form:"email"` // want `field Email2 uses form key "email", which is already used by field Email`
+	Name   string
+	Other  string `form:"Name"` // want `field Other uses form key "Name", which is already used by field Name`
+}
+
+type MalformedTags struct {
+	A string `form:"a,omitempty,"` // want `field A has an invalid form tag: empty tag option`
+	B string `form:"b,omitmepty"`  // want `field B has an invalid form tag: unknown tag option "omitmepty"`
+	C string `form:"c,layout"`     // want `field C has an invalid form tag: tag option "layout" requires a value`
+}
+
+type TimeOptions struct {
+	Date  *time.Time `form:"date,layout=2006-01-02|2006-01-02T15:04"`
+	Stamp time.Time  `form:"stamp,unix"`
+	Name  string     `form:"name,unixmilli"` // want `field Name has an invalid form tag: tag option "unixmilli" requires a time.Time field`
+}
+
+type SliceOptions struct {
+	RGB  [3]float64 `form:"rgb,split=space"`
+	Fns  [2]func()  `form:"fns"` // want `field Fns has type \[2\]func\(\), which m2s cannot decode`
+	Tags []string   `form:"tags,split=,,trim"`
+	IDs  *[]int     `form:"ids,split=space"`
+	Name string     `form:"name,split=,"` // want `field Name has an invalid form tag: tag option "split" requires a slice or array field`
+}
+
+type Remaining struct {
+	Values map[string][]string                `form:",remaining"`
+	Files  map[string][]*multipart.FileHeader `form:",remaining"`
+	Other  map[string]int                     `form:",remaining"` // want `field Other has an invalid form tag: tag option "remaining" requires a map\[string\]\[\]string or map\[string\]\[\]\*multipart.FileHeader field`
+}
+
+type Aliases struct {
+	Email string `form:"email|mail,alias=e_mail"`
+	Mail  string `form:"contact,alias=mail"` // want `field Mail uses form key "mail", which is already used by field Email`
+}
+
+type OptionalOptions struct {
+	Date    m2s.Optional[time.Time]     `form:"date,layout=2006-01-02"`
+	Timeout m2s.Optional[time.Duration] `form:"timeout,unit=s"`
+	Name    m2s.Optional[string]        `form:"name,unix"` // want `field Name has an invalid form tag: tag option "unix" requires a time.Time field`
+}
+
+type BoolOptions struct {
+	Subscribe bool   `form:"subscribe,checkbox"`
+	Terms     *bool  `form:"terms,checkbox"`
+	Name      string `form:"name,checkbox"` // want `field Name has an invalid form tag: tag option "checkbox" requires a bool field`
+}
+
+type DurationOptions struct {
+	Timeout   time.Duration    `form:"timeout,unit=s"`
+	Intervals []*time.Duration `form:"intervals,unit=ms"`
+	Window    [2]time.Duration `form:"window,unit=s"`
+	Count     int              `form:"count,unit=s"` // want `field Count has an invalid form tag: tag option "unit" requires a time.Duration field`
+}
+
+type NumberOptions struct {
+	Price   float64           `form:"price,decimal=,,group=.,currency"`
+	Rate    *float32          `form:"rate,percent"`
+	Mask    uint32            `form:"mask,prefix,underscores"`
+	Amounts []float64         `form:"amounts,split=;,decimal=,"`
+	Timeout time.Duration     `form:"timeout,group=."` // want `field Timeout has an invalid form tag: tag option "group" requires a number field`
+	Name    string            `form:"name,currency"`   // want `field Name has an invalid form tag: tag option "currency" requires a number field`
+	Counts  m2s.Optional[int] `form:"counts,group=space"`
+}
+
+type CheckedValues struct {
+	Name    string        `form:"name,multi=newest"`        // want `field Name has an invalid form tag: unknown multi-value policy "newest"`
+	Timeout time.Duration `form:"timeout,unit=d"`           // want `field Timeout has an invalid form tag: unknown duration unit "d"`
+	Count   int           `form:"count,percent"`            // want `field Count has an invalid form tag: tag option "percent" requires a float field`
+	Price   float64       `form:"price,prefix"`             // want `field Price has an invalid form tag: tag option "prefix" requires an integer field`
+	Tags    []string      `form:"tags,trim"`                // want `field Tags has an invalid form tag: tag option "trim" requires the split option`
+	Amount  float64       `form:"amount,decimal=.,group=."` // want `field Amount has an invalid form tag: decimal and group separators are both "."`
+	Both    string        `form:"both,unix,checkbox"`       // want `field Both has an invalid form tag: tag option "unix" requires a time.Time field` `field Both has an invalid form tag: tag option "checkbox" requires a bool field`
+}
+
+type Modifiers struct {
+	Name  string `form:"name" mod:"trim,lower"`
+	Email string `form:"email" mod:"trim,slugify"` // want `field Email has unknown modifier "slugify"`
+}
+
+type FileTypes struct {
+	File    multipart.File            `form:"file"`    // want `field File has type multipart.File, which is not bound as a file`
+	OSFile  *os.File                  `form:"os_file"` // want `field OSFile has type \*os.File, which is not bound as a file`
+	Nested  [][]*multipart.FileHeader `form:"nested"`  // want `field Nested has type \[\]\[\]\*multipart.FileHeader, which is not bound as a file`
+	Pointer **multipart.FileHeader    `form:"pointer"` // want `field Pointer has type \*\*multipart.FileHeader, which is not bound as a file`
+}
+
+type Untagged struct {
+	Name string
+	Ch   chan int // want `field Ch has type chan int, which m2s cannot decode`
+}
+
+type UntaggedGeneric struct {
+	Fn func() // want `field Fn has type func\(\), which m2s cannot decode`
+}
+
+type UntaggedOptions struct {
+	Ch chan int // want `field Ch has type chan int, which m2s cannot decode`
+}
+
+type NotBound struct {
+	Ch chan int
+}
+
+func handle(mpf *multipart.Form) {
+	var u Untagged
+	_ = m2s.Convert(mpf, &u)
+	var o UntaggedOptions
+	_ = m2s.Convert(mpf, &o, m2s.WithZeroMissing())
+	_, _ = m2s.ConvertTo[*UntaggedGeneric](mpf)
+}
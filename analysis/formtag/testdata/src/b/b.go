@@ -0,0 +1,15 @@
+package b
+
+type Body struct {
+	Name  string `m2s:"name" json:"full_name"`
+	Email string `json:"name"` // want `field Email uses form key "name", which is already used by field Name`
+	Skip  string `json:"-"`
+	Age   int    `m2s:"age,omitmepty"` // want `field Age has an invalid m2s tag: unknown tag option "omitmepty"`
+	Fn    func() `json:"fn"`           // want `field Fn has type func\(\), which m2s cannot decode`
+	Slug  string `m2s:"slug" mod:"trim,slugify"`
+	Title string `m2s:"title" mod:"titlecase"` // want `field Title has unknown modifier "titlecase"`
+}
+
+type JSONOnly struct {
+	Fn func() `json:"fn"`
+}
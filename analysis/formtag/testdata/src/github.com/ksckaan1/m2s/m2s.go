@@ -0,0 +1,21 @@
+// Package m2s is a stub of the real package for the analyzer tests.
+package m2s
+
+import "mime/multipart"
+
+type Option func()
+
+func WithZeroMissing() Option { return nil }
+
+func Convert(mpf *multipart.Form, v any, opts ...Option) error { return nil }
+
+func ConvertTo[T any](mpf *multipart.Form, opts ...Option) (T, error) {
+	var v T
+	return v, nil
+}
+
+type Optional[T any] struct {
+	Value T
+	Set   bool
+	Null  bool
+}
@@ -0,0 +1,13 @@
+module github.com/ksckaan1/m2s/analysis
+
+go 1.22.0
+
+require (
+	github.com/ksckaan1/m2s v0.1.0
+	golang.org/x/tools v0.30.0
+)
+
+require (
+	golang.org/x/mod v0.23.0 // indirect
+	golang.org/x/sync v0.11.0 // indirect
+)
@@ -0,0 +1,222 @@
+package m2s
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// isArrayType reports whether rt is an array or a pointer to one.
+func isArrayType(rt reflect.Type) bool {
+	return indirectType(rt).Kind() == reflect.Array
+}
+
+// arrayValues returns the elements of an array field sent as repeated keys,
+// e.g. rgb=1&rgb=2&rgb=3, or as indexed keys, e.g. rgb[0]=1&rgb[1]=2. It
+// reports false if the field was sent as a single value or not at all.
+func arrayValues(mpf *multipart.Form, f field) ([]string, bool, error) {
+	if formValues := mpf.Value[f.key]; len(formValues) > 0 {
+		return formValues, len(formValues) > 1, nil
+	}
+
+	n := indirectType(f.typ).Len()
+	elems := make([]string, n)
+	seen := make([]bool, n)
+	found := 0
+	for key, formValues := range mpf.Value {
+		index, ok := strings.CutPrefix(key, f.key+"[")
+		if !ok || len(formValues) == 0 {
+			continue
+		}
+		index, ok = strings.CutSuffix(index, "]")
+		if !ok {
+			continue
+		}
+		i, err := strconv.Atoi(index)
+		if err != nil {
+			continue
+		}
+		if strconv.Itoa(i) != index {
+			// e.g. rgb[01] or rgb[+1], which could hide a duplicate index
+			return nil, false, parseError(f, f.typ, CodeLengthMismatch, formValues[0],
+				fmt.Errorf("%w: index %q is not canonical", ErrLengthMismatch, index))
+		}
+		if i < 0 || i >= n {
+			return nil, false, parseError(f, f.typ, CodeLengthMismatch, formValues[0],
+				fmt.Errorf("%w: index %d out of range [0, %d)", ErrLengthMismatch, i, n))
+		}
+		if seen[i] {
+			return nil, false, parseError(f, f.typ, CodeDuplicateValue, formValues[0],
+				fmt.Errorf("%w: index %d", ErrDuplicateValue, i))
+		}
+		elems[i] = formValues[0]
+		seen[i] = true
+		found++
+	}
+
+	if found == 0 {
+		return nil, false, nil
+	}
+	if found != n {
+		return nil, false, lengthError(f, strings.Join(elems, ","), found, n)
+	}
+	return elems, true, nil
+}
+
+// convertArray converts a single form value into an array field: a JSON
+// array, the elements of the split tag option, or a single element.
+func (c *config) convertArray(fieldType reflect.Type, fieldValue reflect.Value, f field, formValue string) error {
+	split := isSplit(f) && fieldType == indirectType(f.typ)
+	if strings.HasPrefix(strings.TrimSpace(formValue), "[") && !split {
+		var elems []json.RawMessage
+		err := json.Unmarshal(string2ByteSlice(formValue), &elems)
+		if err != nil {
+			return parseError(f, fieldType, CodeParseJSON, formValue, err)
+		}
+		if len(elems) != fieldType.Len() {
+			return lengthError(f, formValue, len(elems), fieldType.Len())
+		}
+		arr := reflect.New(fieldType).Elem()
+		err = c.convertJSONElems(arr, f, elems)
+		if err != nil {
+			return err
+		}
+		fieldValue.Set(arr)
+		return nil
+	}
+
+	elems := []string{formValue}
+	if split {
+		elems = splitValue(f, formValue)
+	}
+	return c.convertElems(fieldType, fieldValue, f, elems)
+}
+
+// convertJSONElems converts the elements of a JSON array into list, a slice
+// or array of the same length, like form values, so that tag options such
+// as unit and layout apply to them. Strings are unquoted, null leaves an
+// element zero and JSON numbers are read as such, without the number
+// format of f.
+func (c *config) convertJSONElems(list reflect.Value, f field, elems []json.RawMessage) error {
+	elemType := list.Type().Elem()
+	for i, elem := range elems {
+		if string(elem) == "null" {
+			continue
+		}
+		if isJSONNumber(elem) && isPlainNumberType(elemType) {
+			err := json.Unmarshal(elem, list.Index(i).Addr().Interface())
+			if err != nil {
+				return parseError(f.elem(i), elemType, CodeParseJSON, string(elem), err)
+			}
+			continue
+		}
+		s := string(elem)
+		if elem[0] == '"' {
+			err := json.Unmarshal(elem, &s)
+			if err != nil {
+				return parseError(f.elem(i), elemType, CodeParseJSON, string(elem), err)
+			}
+		}
+		err := c.convertValue(elemType, list.Index(i), f.elem(i), s)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isJSONNumber reports whether elem, an element of a JSON array, is a
+// number.
+func isJSONNumber(elem json.RawMessage) bool {
+	return elem[0] == '-' || '0' <= elem[0] && elem[0] <= '9'
+}
+
+// isPlainNumberType reports whether rt is an integer or float type or a
+// pointer to one, but not time.Duration.
+func isPlainNumberType(rt reflect.Type) bool {
+	rt = indirectType(rt)
+	return rt != durationType && (isIntegerKind(rt.Kind()) || isFloatKind(rt.Kind()))
+}
+
+// encodeArray encodes an array, or a slice of durations, as a JSON array,
+// the inverse of convertJSONElems. Elements are encoded like form values,
+// so that tag options apply to them.
+func (c *config) encodeArray(f field, rv reflect.Value) (string, error) {
+	_, unit := f.opts.Lookup("unit")
+	elems := make([]any, rv.Len())
+	for i := range elems {
+		ev := rv.Index(i)
+		for ev.Kind() == reflect.Pointer && !ev.IsNil() {
+			ev = ev.Elem()
+		}
+		if ev.Kind() == reflect.Pointer {
+			continue // nil pointers stay null
+		}
+		if isPlainNumberType(ev.Type()) || ev.Kind() == reflect.Bool {
+			elems[i] = ev.Interface()
+			continue
+		}
+		s, err := c.encodeValue(f.elem(i), ev)
+		if err != nil {
+			return "", err
+		}
+		switch kind := decoderKind(ev.Type()); {
+		case kind == DecoderJSON || kind == DecoderArray:
+			elems[i] = json.RawMessage(s)
+		case kind == DecoderDuration && unit:
+			elems[i] = json.Number(s)
+		default:
+			elems[i] = s
+		}
+	}
+	b, err := json.Marshal(elems)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// convertElems converts each element into the array fieldValue, which must
+// have exactly as many elements.
+func (c *config) convertElems(fieldType reflect.Type, fieldValue reflect.Value, f field, elems []string) error {
+	if len(elems) != fieldType.Len() {
+		return lengthError(f, strings.Join(elems, ","), len(elems), fieldType.Len())
+	}
+
+	arr := reflect.New(fieldType).Elem()
+	for i, elem := range elems {
+		err := c.convertValue(fieldType.Elem(), arr.Index(i), f.elem(i), elem)
+		if err != nil {
+			return err
+		}
+	}
+	fieldValue.Set(arr)
+	return nil
+}
+
+// convertArrayValues converts the elements returned by arrayValues into an
+// array field or a pointer to one.
+func (c *config) convertArrayValues(f field, fieldValue reflect.Value, elems []string) error {
+	arr := reflect.New(indirectType(f.typ)).Elem()
+	err := c.convertElems(arr.Type(), arr, f, elems)
+	if err != nil {
+		return err
+	}
+
+	v := arr
+	for v.Type() != f.typ {
+		ptr := reflect.New(v.Type())
+		ptr.Elem().Set(v)
+		v = ptr
+	}
+	fieldValue.Set(v)
+	return nil
+}
+
+func lengthError(f field, formValue string, got, want int) error {
+	return parseError(f, f.typ, CodeLengthMismatch, formValue,
+		fmt.Errorf("%w: got %d values, want %d", ErrLengthMismatch, got, want))
+}
@@ -0,0 +1,47 @@
+package m2s
+
+import (
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Words accepted for bool fields in addition to the forms of
+// strconv.ParseBool, compared case-insensitively. They cover what HTML
+// checkboxes and radio buttons commonly post.
+var (
+	DefaultTruthyWords = []string{"on", "yes", "checked"}
+	DefaultFalsyWords  = []string{"off", "no"}
+)
+
+// parseBool parses formValue with strconv.ParseBool, falling back to the
+// truthy and falsy words of c. The error of strconv.ParseBool is returned
+// if neither matches.
+func (c *config) parseBool(formValue string) (bool, error) {
+	v, err := strconv.ParseBool(formValue)
+	if err == nil {
+		return v, nil
+	}
+	word := strings.ToLower(strings.TrimSpace(formValue))
+	switch {
+	case slices.Contains(c.truthyWords, word):
+		return true, nil
+	case slices.Contains(c.falsyWords, word):
+		return false, nil
+	}
+	return false, err
+}
+
+// isUnchecked reports whether rv is a false bool, following pointers.
+func isUnchecked(rv reflect.Value) bool {
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	return rv.Kind() == reflect.Bool && !rv.Bool()
+}
+
+// isBoolType reports whether rt is a bool or a pointer to one.
+func isBoolType(rt reflect.Type) bool {
+	return indirectType(rt).Kind() == reflect.Bool
+}
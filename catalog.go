@@ -0,0 +1,143 @@
+package m2s
+
+import (
+	"cmp"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// MessageCatalog renders the message for an error code in a specific
+// language. It returns an empty string if it has no message for the code.
+type MessageCatalog interface {
+	Message(code string, params map[string]any) string
+}
+
+// MapCatalog is a MessageCatalog backed by a map from error codes to message
+// templates. Placeholders like {key} are replaced with the matching
+// parameter of the error.
+type MapCatalog map[string]string
+
+func (c MapCatalog) Message(code string, params map[string]any) string {
+	msg, ok := c[code]
+	if !ok {
+		return ""
+	}
+
+	oldnew := make([]string, 0, len(params)*2)
+	for k, v := range params {
+		oldnew = append(oldnew, "{"+k+"}", fmt.Sprint(v))
+	}
+	return strings.NewReplacer(oldnew...).Replace(msg)
+}
+
+// English is the bundled English message catalog.
+var English = MapCatalog{
+	CodeUnknown:         "unknown error",
+	CodeValueNotPointer: "value must be a pointer",
+	CodeValueNil:        "value cannot be nil",
+	CodeValueNotStruct:  "value must be a struct",
+	CodeInvalidType:     "invalid field type",
+	CodeInvalidTag:      "invalid form tag",
+	CodeFormInvalid:     "request is not a valid multipart form",
+	CodeFormTooLarge:    "request body is too large",
+	CodeInvalidFields:   "the multipart form contains invalid fields",
+	CodeUnknownFields:   "unknown form fields: {keys}",
+	CodeParseText:       "{key} has an invalid format",
+	CodeParseInt:        "{key} must be an integer",
+	CodeParseUint:       "{key} must be a non-negative integer",
+	CodeParseFloat:      "{key} must be a number",
+	CodeParseBool:       "{key} must be true or false",
+	CodeParseComplex:    "{key} must be a complex number",
+	CodeParseJSON:       "{key} must be valid JSON",
+	CodeParseTime:       "{key} must be a valid time",
+	CodeParseDuration:   "{key} must be a duration such as 90s or 1h30m",
+	CodeEmptyValue:      "{key} cannot be empty",
+	CodeDuplicateValue:  "{key} must be sent only once",
+	CodeLengthMismatch:  "{key} has the wrong number of values",
+}
+
+// Turkish is the bundled Turkish message catalog.
+var Turkish = MapCatalog{
+	CodeUnknown:         "bilinmeyen hata",
+	CodeValueNotPointer: "değer bir işaretçi olmalıdır",
+	CodeValueNil:        "değer nil olamaz",
+	CodeValueNotStruct:  "değer bir struct olmalıdır",
+	CodeInvalidType:     "geçersiz alan tipi",
+	CodeInvalidTag:      "geçersiz form etiketi",
+	CodeFormInvalid:     "istek geçerli bir multipart form değil",
+	CodeFormTooLarge:    "istek gövdesi çok büyük",
+	CodeInvalidFields:   "multipart form geçersiz alanlar içeriyor",
+	CodeUnknownFields:   "bilinmeyen form alanları: {keys}",
+	CodeParseText:       "{key} geçersiz biçimde",
+	CodeParseInt:        "{key} bir tam sayı olmalıdır",
+	CodeParseUint:       "{key} negatif olmayan bir tam sayı olmalıdır",
+	CodeParseFloat:      "{key} bir sayı olmalıdır",
+	CodeParseBool:       "{key} true ya da false olmalıdır",
+	CodeParseComplex:    "{key} bir karmaşık sayı olmalıdır",
+	CodeParseJSON:       "{key} geçerli bir JSON olmalıdır",
+	CodeParseTime:       "{key} geçerli bir zaman olmalıdır",
+	CodeParseDuration:   "{key} 90s ya da 1h30m gibi bir süre olmalıdır",
+	CodeEmptyValue:      "{key} boş olamaz",
+	CodeDuplicateValue:  "{key} yalnızca bir kez gönderilmelidir",
+	CodeLengthMismatch:  "{key} yanlış sayıda değer içeriyor",
+}
+
+// Catalogs maps language subtags to the catalogs CatalogFor chooses from.
+// Add entries to it to support more languages.
+var Catalogs = map[string]MessageCatalog{
+	"en": English,
+	"tr": Turkish,
+}
+
+// Message renders err with the given catalog. It falls back to err.Error()
+// if the catalog is nil or has no message for the code of err.
+func Message(err error, catalog MessageCatalog) string {
+	if catalog == nil {
+		return err.Error()
+	}
+	return cmp.Or(catalog.Message(ErrorCode(err), errorParams(err)), err.Error())
+}
+
+// CatalogFor returns the entry of Catalogs that best matches the
+// Accept-Language header of r, or English if none matches.
+func CatalogFor(r *http.Request) MessageCatalog {
+	return Catalogs[negotiateLanguage(r)]
+}
+
+// negotiateLanguage returns the key of Catalogs that best matches the
+// Accept-Language header of r, or "en" if none matches.
+func negotiateLanguage(r *http.Request) string {
+	type language struct {
+		tag string
+		q   float64
+	}
+
+	var langs []language
+	for _, part := range strings.Split(r.Header.Get("Accept-Language"), ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			var err error
+			q, err = strconv.ParseFloat(v, 64)
+			if err != nil {
+				continue
+			}
+		}
+		primary, _, _ := strings.Cut(tag, "-")
+		langs = append(langs, language{strings.ToLower(primary), q})
+	}
+
+	slices.SortStableFunc(langs, func(a, b language) int {
+		return cmp.Compare(b.q, a.q)
+	})
+
+	for _, lang := range langs {
+		if _, ok := Catalogs[lang.tag]; ok && lang.q > 0 {
+			return lang.tag
+		}
+	}
+	return "en"
+}
@@ -0,0 +1,96 @@
+package m2s
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestBundledCatalogs(t *testing.T) {
+	for code := range English {
+		if _, ok := Turkish[code]; !ok {
+			t.Errorf("Turkish catalog has no message for %q", code)
+		}
+	}
+	for code := range Turkish {
+		if _, ok := English[code]; !ok {
+			t.Errorf("English catalog has no message for %q", code)
+		}
+	}
+}
+
+func TestErrorCode(t *testing.T) {
+	tests := []struct {
+		err  error
+		want string
+	}{
+		{ErrValueMustBePointer, CodeValueNotPointer},
+		{ErrValueCannotBeNil, CodeValueNil},
+		{ErrValueMustBeStruct, CodeValueNotStruct},
+		{fmt.Errorf("field Ch: %w", ErrInvalidFieldType), CodeInvalidType},
+		{ErrField{Field: "Ch", Key: "ch", Err: ErrInvalidFieldType}, CodeInvalidType},
+		{ErrParseFailed{Field: "Age", Key: "age", Code: CodeParseInt, Err: errors.New("invalid syntax")}, CodeParseInt},
+		{http.ErrNotMultipart, CodeFormInvalid},
+		{&http.MaxBytesError{Limit: 10}, CodeFormTooLarge},
+		{errors.New("other"), CodeUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.err.Error(), func(t *testing.T) {
+			if got := ErrorCode(tt.err); got != tt.want {
+				t.Errorf("ErrorCode() got = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMessage(t *testing.T) {
+	err := ErrParseFailed{Field: "Age", Key: "age", Code: CodeParseInt, Err: errors.New("invalid syntax")}
+
+	tests := []struct {
+		name    string
+		err     error
+		catalog MessageCatalog
+		want    string
+	}{
+		{"nil catalog", err, nil, "failed to parse field Age: invalid syntax"},
+		{"english", err, English, "age must be an integer"},
+		{"turkish", err, Turkish, "age bir tam sayı olmalıdır"},
+		{"sentinel", ErrValueCannotBeNil, Turkish, "değer nil olamaz"},
+		{"missing message", err, MapCatalog{}, "failed to parse field Age: invalid syntax"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Message(tt.err, tt.catalog); got != tt.want {
+				t.Errorf("Message() got = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCatalogFor(t *testing.T) {
+	tests := []struct {
+		acceptLanguage string
+		want           string
+	}{
+		{"", "en"},
+		{"tr-TR", "tr"},
+		{"de-DE, tr;q=0.8, en;q=0.5", "tr"},
+		{"en;q=0.5, tr;q=0.9", "tr"},
+		{"tr;q=0, en", "en"},
+		{"fr", "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.acceptLanguage, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/", nil)
+			r.Header.Set("Accept-Language", tt.acceptLanguage)
+			if got := negotiateLanguage(r); got != tt.want {
+				t.Errorf("negotiateLanguage() got = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package m2s
+
+import (
+	"mime/multipart"
+	"net/http"
+	"reflect"
+)
+
+// Decoder converts multipart forms into values of type T. T must be a
+// struct or a pointer to a struct. The type and its fields are validated
+// once by NewDecoder, so a Decoder never fails with ErrValueMustBeStruct,
+// ErrInvalidFieldType or ErrInvalidTag while decoding.
+type Decoder[T any] struct {
+	cfg    *config
+	rt     reflect.Type
+	ptr    bool
+	fields []field
+}
+
+// NewDecoder returns a Decoder for T. It fails if T is not a struct or a
+// pointer to a struct, or if one of its fields has a type that cannot be
+// decoded or a malformed form tag.
+func NewDecoder[T any](opts ...Option) (*Decoder[T], error) {
+	d := &Decoder[T]{
+		cfg: newConfig(opts),
+		rt:  reflect.TypeFor[T](),
+	}
+
+	if d.rt.Kind() == reflect.Pointer {
+		d.ptr = true
+		d.rt = d.rt.Elem()
+	}
+
+	if d.rt.Kind() != reflect.Struct {
+		return nil, ErrValueMustBeStruct
+	}
+
+	d.fields = d.cfg.structFields(d.rt)
+
+	for _, f := range d.fields {
+		err := d.cfg.validateField(f)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return d, nil
+}
+
+// Decode converts mpf into a new value of type T.
+func (d *Decoder[T]) Decode(mpf *multipart.Form) (T, error) {
+	var v T
+	err := d.DecodeInto(mpf, &v)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return v, nil
+}
+
+// DecodeInto converts mpf into the value v points to. If T is a pointer
+// type and *v is nil, a new struct is allocated.
+func (d *Decoder[T]) DecodeInto(mpf *multipart.Form, v *T) error {
+	if v == nil {
+		return ErrValueCannotBeNil
+	}
+
+	rv := reflect.ValueOf(v).Elem()
+	if d.ptr {
+		if rv.IsNil() {
+			rv.Set(reflect.New(d.rt))
+		}
+		rv = rv.Elem()
+	}
+
+	return d.cfg.decode(mpf, rv, d.fields)
+}
+
+// ConvertTo converts mpf into a new value of type T. It is a shorthand for
+// NewDecoder followed by Decode.
+func ConvertTo[T any](mpf *multipart.Form, opts ...Option) (T, error) {
+	d, err := NewDecoder[T](opts...)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return d.Decode(mpf)
+}
+
+// Bind parses the multipart form of r, keeping up to maxMemory bytes of
+// file parts in memory, and converts it into a new value of type T.
+func Bind[T any](r *http.Request, maxMemory int64, opts ...Option) (T, error) {
+	d, err := NewDecoder[T](opts...)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	err = r.ParseMultipartForm(maxMemory)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+
+	return d.Decode(r.MultipartForm)
+}
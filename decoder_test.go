@@ -0,0 +1,232 @@
+package m2s
+
+import (
+	"errors"
+	"mime/multipart"
+	"reflect"
+	"testing"
+)
+
+func TestNewDecoder(t *testing.T) {
+	type Body struct {
+		Name string `form:"name"`
+	}
+
+	t.Run("struct type", func(t *testing.T) {
+		_, err := NewDecoder[Body]()
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	})
+
+	t.Run("pointer to struct type", func(t *testing.T) {
+		_, err := NewDecoder[*Body]()
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+	})
+
+	t.Run("error when non-struct type", func(t *testing.T) {
+		_, err := NewDecoder[string]()
+		if !errors.Is(err, ErrValueMustBeStruct) {
+			t.Fatal("unexpected error:", err)
+		}
+	})
+
+	t.Run("error when invalid field type", func(t *testing.T) {
+		_, err := NewDecoder[struct {
+			Ch chan int `form:"ch"`
+		}]()
+		if !errors.Is(err, ErrInvalidFieldType) {
+			t.Fatal("unexpected error:", err)
+		}
+	})
+
+	t.Run("error when time option on non-time field", func(t *testing.T) {
+		_, err := NewDecoder[struct {
+			Name string `form:"name,layout=2006-01-02"`
+		}]()
+		if !errors.Is(err, ErrInvalidTag) {
+			t.Fatal("unexpected error:", err)
+		}
+	})
+
+	t.Run("error when unknown multi-value policy", func(t *testing.T) {
+		_, err := NewDecoder[struct {
+			Name string `form:"name,multi=random"`
+		}]()
+		if !errors.Is(err, ErrInvalidTag) {
+			t.Fatal("unexpected error:", err)
+		}
+	})
+
+	t.Run("error when split option on non-slice field", func(t *testing.T) {
+		_, err := NewDecoder[struct {
+			Name string `form:"name,split=,"`
+		}]()
+		if !errors.Is(err, ErrInvalidTag) {
+			t.Fatal("unexpected error:", err)
+		}
+	})
+
+	t.Run("error when split elements cannot be decoded", func(t *testing.T) {
+		_, err := NewDecoder[struct {
+			Fns []func() `form:"fns,split=,"`
+		}]()
+		if !errors.Is(err, ErrInvalidFieldType) {
+			t.Fatal("unexpected error:", err)
+		}
+	})
+
+	t.Run("error when remaining option on unsupported type", func(t *testing.T) {
+		_, err := NewDecoder[struct {
+			Extra map[string]string `form:",remaining"`
+		}]()
+		if !errors.Is(err, ErrInvalidTag) {
+			t.Fatal("unexpected error:", err)
+		}
+	})
+
+	t.Run("error when alias is empty", func(t *testing.T) {
+		_, err := NewDecoder[struct {
+			Email string `form:"email||mail"`
+		}]()
+		if !errors.Is(err, ErrInvalidTag) {
+			t.Fatal("unexpected error:", err)
+		}
+	})
+
+	t.Run("error when number option on non-number field", func(t *testing.T) {
+		_, err := NewDecoder[struct {
+			Name string `form:"name,decimal=,"`
+		}]()
+		if !errors.Is(err, ErrInvalidTag) {
+			t.Fatal("unexpected error:", err)
+		}
+	})
+
+	t.Run("error when percent option on integer field", func(t *testing.T) {
+		_, err := NewDecoder[struct {
+			Rate int `form:"rate,percent"`
+		}]()
+		if !errors.Is(err, ErrInvalidTag) {
+			t.Fatal("unexpected error:", err)
+		}
+	})
+
+	t.Run("error when decimal and group separators are equal", func(t *testing.T) {
+		_, err := NewDecoder[struct {
+			Price float64 `form:"price,group=,"`
+		}](WithNumberFormat(NumberFormat{Decimal: ","}))
+		if !errors.Is(err, ErrInvalidTag) {
+			t.Fatal("unexpected error:", err)
+		}
+	})
+
+	t.Run("error when invalid tag option", func(t *testing.T) {
+		_, err := NewDecoder[struct {
+			Name string `form:"name,omitmepty"`
+		}]()
+		var ferr ErrField
+		if !errors.As(err, &ferr) || !errors.Is(err, ErrInvalidTag) || ferr.Key != "name" {
+			t.Fatal("unexpected error:", err)
+		}
+	})
+}
+
+func TestDecoder(t *testing.T) {
+	type Body struct {
+		Name string                `form:"name"`
+		Age  *int                  `form:"age"`
+		File *multipart.FileHeader `form:"file"`
+	}
+
+	mpf := &multipart.Form{
+		Value: map[string][]string{
+			"name": {"John"},
+			"age":  {"42"},
+		},
+		File: map[string][]*multipart.FileHeader{
+			"file": {{Filename: "file.txt"}},
+		},
+	}
+
+	want := Body{
+		Name: "John",
+		Age:  ptr(42),
+		File: &multipart.FileHeader{Filename: "file.txt"},
+	}
+
+	t.Run("decode struct", func(t *testing.T) {
+		got, err := ConvertTo[Body](mpf)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("ConvertTo() got = %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("decode pointer to struct", func(t *testing.T) {
+		got, err := ConvertTo[*Body](mpf)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if !reflect.DeepEqual(got, &want) {
+			t.Errorf("ConvertTo() got = %+v, want %+v", got, &want)
+		}
+	})
+
+	t.Run("decode into existing value", func(t *testing.T) {
+		d, err := NewDecoder[Body]()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := Body{Name: "Jane"}
+		err = d.DecodeInto(&multipart.Form{Value: map[string][]string{"age": {"42"}}}, &got)
+		if err != nil {
+			t.Fatal("unexpected error:", err)
+		}
+		if got.Name != "Jane" || got.Age == nil || *got.Age != 42 {
+			t.Errorf("DecodeInto() got = %+v", got)
+		}
+	})
+
+	t.Run("error when decoding into nil", func(t *testing.T) {
+		d, err := NewDecoder[Body]()
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = d.DecodeInto(mpf, nil)
+		if !errors.Is(err, ErrValueCannotBeNil) {
+			t.Fatal("unexpected error:", err)
+		}
+	})
+
+	t.Run("error when parsing value", func(t *testing.T) {
+		got, err := ConvertTo[Body](&multipart.Form{Value: map[string][]string{"age": {"invalid"}}})
+		var terr ErrParseFailed
+		if !errors.As(err, &terr) || terr.Field != "Age" {
+			t.Fatal("unexpected error:", err)
+		}
+		if !reflect.DeepEqual(got, Body{}) {
+			t.Errorf("ConvertTo() got = %+v, want zero value", got)
+		}
+	})
+}
+
+func TestBind(t *testing.T) {
+	type Body struct {
+		Name string `form:"name"`
+	}
+
+	r := newMultipartRequest(t, map[string]string{"name": "John"}, nil)
+
+	got, err := Bind[Body](r, 32<<20)
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if got.Name != "John" {
+		t.Errorf("Bind() got = %+v", got)
+	}
+}
@@ -0,0 +1,114 @@
+package m2s
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// DecoderKind tells how a form value is converted into a struct field.
+type DecoderKind uint
+
+const (
+	DecoderNone      DecoderKind = iota // files, catch-all fields, or a type that cannot be decoded
+	DecoderPrimitive                    // strconv for numbers and bools, as-is for strings
+	DecoderText                         // encoding.TextUnmarshaler
+	DecoderJSON                         // encoding/json
+	DecoderTime                         // time layouts or Unix timestamps
+	DecoderDuration                     // time.ParseDuration, for durations and slices of them
+	DecoderSplit                        // delimited values, each converted by the decoder of the element type
+	DecoderArray                        // repeated keys, indexed keys or JSON, for arrays
+)
+
+func (k DecoderKind) String() string {
+	switch k {
+	case DecoderNone:
+		return "none"
+	case DecoderPrimitive:
+		return "primitive"
+	case DecoderText:
+		return "text"
+	case DecoderJSON:
+		return "json"
+	case DecoderTime:
+		return "time"
+	case DecoderDuration:
+		return "duration"
+	case DecoderSplit:
+		return "split"
+	case DecoderArray:
+		return "array"
+	default:
+		return "DecoderKind(" + strconv.Itoa(int(k)) + ")"
+	}
+}
+
+// FieldInfo describes how Convert binds a single struct field.
+type FieldInfo struct {
+	Key     string       // form key
+	Aliases []string     // alternative keys, in order of priority
+	Path    string       // Go path of the field
+	Type    reflect.Type // Go type of the field
+	Kind    FieldKind    // value, single file or multiple files
+	Decoder DecoderKind  // DecoderNone for file fields
+	Options []string     // tag options, e.g. "omitempty"
+	Mods    []string     // modifiers of the mod tag, e.g. "trim"
+	Err     error        // non-nil if the field has an invalid type or tag
+}
+
+// Describe lists every field of v that Convert binds. v must be a struct or
+// a pointer to a struct; a nil pointer of the right type is enough. Fields
+// whose type cannot be decoded are reported with a non-nil Err instead of
+// failing, and so are fields with malformed tags, so that every problem is
+// visible at once.
+func Describe(v any, opts ...Option) ([]FieldInfo, error) {
+	rt := reflect.TypeOf(v)
+	if rt == nil {
+		return nil, ErrValueCannotBeNil
+	}
+	if rt.Kind() == reflect.Pointer {
+		rt = rt.Elem()
+	}
+	if rt.Kind() != reflect.Struct {
+		return nil, ErrValueMustBeStruct
+	}
+
+	c := newConfig(opts)
+	fields := c.structFields(rt)
+	infos := make([]FieldInfo, 0, len(fields))
+
+	for _, f := range fields {
+		info := FieldInfo{
+			Key:     f.key,
+			Aliases: f.aliases,
+			Path:    f.path,
+			Type:    f.typ,
+			Kind:    f.kind,
+			Options: f.opts.List(),
+			Mods:    f.mods,
+		}
+
+		if f.kind == FieldValue {
+			info.Decoder = c.decoderKind(f)
+		}
+		info.Err = c.validateField(f)
+
+		infos = append(infos, info)
+	}
+
+	return infos, nil
+}
+
+// decoderKind returns the decoder convertValue uses for f.
+func (c *config) decoderKind(f field) DecoderKind {
+	if isRemaining(f) || f.typ == presenceType {
+		return DecoderNone
+	}
+	f = f.withoutOptional()
+	if isSplit(f) {
+		return DecoderSplit
+	}
+	if indirectType(f.typ) == timeType && c.usesTimeParser(f) {
+		return DecoderTime
+	}
+	return decoderKind(f.typ)
+}
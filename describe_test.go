@@ -0,0 +1,152 @@
+package m2s
+
+import (
+	"errors"
+	"mime/multipart"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDescribe(t *testing.T) {
+	type Body struct {
+		Name    string                 `form:"name,omitempty"`
+		Age     *int                   `form:"age"`
+		Born    time.Time              `form:"born"`
+		Tags    []string               `form:"tags"`
+		Photo   *multipart.FileHeader  `form:"photo"`
+		Photos  []multipart.FileHeader `form:"photos"`
+		Handler func()                 `form:"handler"`
+		Ignored string                 `form:"-"`
+		Untaged bool
+		private string
+	}
+
+	got, err := Describe((*Body)(nil))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	want := []FieldInfo{
+		{Key: "name", Path: "Name", Type: reflect.TypeFor[string](), Kind: FieldValue, Decoder: DecoderPrimitive, Options: []string{"omitempty"}},
+		{Key: "age", Path: "Age", Type: reflect.TypeFor[*int](), Kind: FieldValue, Decoder: DecoderPrimitive},
+		{Key: "born", Path: "Born", Type: reflect.TypeFor[time.Time](), Kind: FieldValue, Decoder: DecoderText},
+		{Key: "tags", Path: "Tags", Type: reflect.TypeFor[[]string](), Kind: FieldValue, Decoder: DecoderJSON},
+		{Key: "photo", Path: "Photo", Type: reflect.TypeFor[*multipart.FileHeader](), Kind: FieldFile, Decoder: DecoderNone},
+		{Key: "photos", Path: "Photos", Type: reflect.TypeFor[[]multipart.FileHeader](), Kind: FieldFiles, Decoder: DecoderNone},
+		{Key: "handler", Path: "Handler", Type: reflect.TypeFor[func()](), Kind: FieldValue, Decoder: DecoderNone},
+		{Key: "Untaged", Path: "Untaged", Type: reflect.TypeFor[bool](), Kind: FieldValue, Decoder: DecoderPrimitive},
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("Describe() got %d fields, want %d", len(got), len(want))
+	}
+
+	for i := range want {
+		gotErr := got[i].Err
+		got[i].Err = nil
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Errorf("Describe()[%d] got = %+v, want %+v", i, got[i], want[i])
+		}
+
+		if want[i].Key == "handler" {
+			if !errors.Is(gotErr, ErrInvalidFieldType) {
+				t.Errorf("Describe()[%d] unexpected error: %v", i, gotErr)
+			}
+		} else if gotErr != nil {
+			t.Errorf("Describe()[%d] unexpected error: %v", i, gotErr)
+		}
+	}
+}
+
+func TestDescribeModifiers(t *testing.T) {
+	type Body struct {
+		Name string `form:"name" mod:"trim,lower"`
+		Code string `form:"code" mod:"slug"`
+	}
+
+	got, err := Describe(Body{}, WithModifier("slug", strings.ToLower))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if !reflect.DeepEqual(got[0].Mods, []string{"trim", "lower"}) || got[1].Err != nil {
+		t.Errorf("Describe() got = %+v", got)
+	}
+
+	got, err = Describe(Body{})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if !errors.Is(got[1].Err, ErrInvalidTag) {
+		t.Errorf("Describe()[1] unexpected error: %v", got[1].Err)
+	}
+}
+
+func TestDescribeTimeDecoder(t *testing.T) {
+	type Body struct {
+		Born  time.Time `form:"born,layout=2006-01-02"`
+		Stamp time.Time `form:"stamp"`
+	}
+
+	got, err := Describe(Body{})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if got[0].Decoder != DecoderTime || got[1].Decoder != DecoderText {
+		t.Errorf("Describe() got = %v, %v", got[0].Decoder, got[1].Decoder)
+	}
+
+	got, err = Describe(Body{}, WithTimeLayouts(time.DateOnly))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+	if got[1].Decoder != DecoderTime {
+		t.Errorf("Describe() got = %v", got[1].Decoder)
+	}
+}
+
+func TestDescribeDurations(t *testing.T) {
+	got, err := Describe(struct {
+		Timeout   time.Duration   `form:"timeout,unit=s"`
+		Intervals []time.Duration `form:"intervals,unit=fortnight"`
+		Count     int             `form:"count,unit=s"`
+	}{})
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if got[0].Decoder != DecoderDuration || got[0].Err != nil {
+		t.Errorf("Describe()[0] got = %v, %v", got[0].Decoder, got[0].Err)
+	}
+	if got[1].Decoder != DecoderDuration || !errors.Is(got[1].Err, ErrInvalidTag) {
+		t.Errorf("Describe()[1] got = %v, %v", got[1].Decoder, got[1].Err)
+	}
+	if !errors.Is(got[2].Err, ErrInvalidTag) {
+		t.Errorf("Describe()[2] unexpected error: %v", got[2].Err)
+	}
+}
+
+func TestDescribeErrors(t *testing.T) {
+	_, err := Describe(nil)
+	if !errors.Is(err, ErrValueCannotBeNil) {
+		t.Fatal("unexpected error:", err)
+	}
+
+	_, err = Describe(42)
+	if !errors.Is(err, ErrValueMustBeStruct) {
+		t.Fatal("unexpected error:", err)
+	}
+}
+
+func TestKindStrings(t *testing.T) {
+	if s := FieldFiles.String(); s != "files" {
+		t.Errorf("FieldFiles.String() got = %q", s)
+	}
+	if s := DecoderJSON.String(); s != "json" {
+		t.Errorf("DecoderJSON.String() got = %q", s)
+	}
+	if s := FieldKind(42).String(); s != "FieldKind(42)" {
+		t.Errorf("FieldKind(42).String() got = %q", s)
+	}
+}
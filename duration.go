@@ -0,0 +1,68 @@
+package m2s
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"time"
+)
+
+var durationType = reflect.TypeFor[time.Duration]()
+
+// durationUnits maps the values of the unit tag option to their duration.
+var durationUnits = map[string]time.Duration{
+	"ns": time.Nanosecond,
+	"us": time.Microsecond,
+	"µs": time.Microsecond,
+	"ms": time.Millisecond,
+	"s":  time.Second,
+	"m":  time.Minute,
+	"h":  time.Hour,
+}
+
+// isDurationType reports whether rt is time.Duration, a pointer to it or a
+// slice or array of either.
+func isDurationType(rt reflect.Type) bool {
+	return numberElem(rt) == durationType
+}
+
+// parseDuration parses formValue with time.ParseDuration. Bare numbers such
+// as "90" or "1.5" are read in the unit of the unit tag option of f; without
+// it they are rejected, except for "0".
+func parseDuration(f field, formValue string) (time.Duration, error) {
+	if unit, ok := f.opts.Lookup("unit"); ok && isBareNumber(formValue) {
+		formValue += unit
+	}
+	return time.ParseDuration(formValue)
+}
+
+// formatDuration is the inverse of parseDuration.
+func formatDuration(f field, d time.Duration) string {
+	if unit, ok := f.opts.Lookup("unit"); ok {
+		return strconv.FormatFloat(float64(d)/float64(durationUnits[unit]), 'f', -1, 64)
+	}
+	return d.String()
+}
+
+// convertDurations decodes a JSON array of durations into a slice field.
+// Elements may be strings such as "90s" or, with the unit tag option, bare
+// numbers.
+func (c *config) convertDurations(fieldType reflect.Type, fieldValue reflect.Value, f field, formValue string) error {
+	var elems []json.RawMessage
+	err := json.Unmarshal(string2ByteSlice(formValue), &elems)
+	if err != nil {
+		return parseError(f, fieldType, CodeParseJSON, formValue, err)
+	}
+
+	list := reflect.MakeSlice(fieldType, len(elems), len(elems))
+	err = c.convertJSONElems(list, f, elems)
+	if err != nil {
+		return err
+	}
+	fieldValue.Set(list)
+	return nil
+}
+
+func isBareNumber(s string) bool {
+	return s != "" && s[len(s)-1] >= '0' && s[len(s)-1] <= '9'
+}
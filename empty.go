@@ -0,0 +1,49 @@
+package m2s
+
+import "reflect"
+
+// EmptyPolicy tells how Convert handles a form value that is the empty
+// string, e.g. an optional number input the user left blank.
+type EmptyPolicy uint
+
+const (
+	// EmptyAsValue converts empty values like any other value: string
+	// fields become "", while number and bool fields fail to parse. This is
+	// the default.
+	EmptyAsValue EmptyPolicy = iota
+	// EmptyAsAbsent leaves the field untouched, as if the key was not sent.
+	EmptyAsAbsent
+	// EmptyAsZero sets the field to its zero value; pointers become nil.
+	EmptyAsZero
+	// EmptyAsError fails with ErrEmptyValue, even for string fields.
+	EmptyAsError
+)
+
+// convertSpecial applies the empty policy and the null option of c to
+// formValue; EmptyAsAbsent is handled by decodeField. It reports whether
+// the value was handled and convertValue must not be called.
+func (c *config) convertSpecial(f field, fieldValue reflect.Value, formValue string) (bool, error) {
+	if formValue == "" {
+		switch c.emptyPolicy {
+		case EmptyAsZero:
+			if o, ok := asOptional(fieldValue); ok {
+				o.setZero() // sent, so still set
+				return true, nil
+			}
+			fieldValue.SetZero()
+			return true, nil
+		case EmptyAsError:
+			return true, parseError(f, f.typ, CodeEmptyValue, formValue, ErrEmptyValue)
+		}
+	}
+
+	if c.nullAsNil && formValue == "null" {
+		switch f.typ.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Map:
+			fieldValue.SetZero()
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,178 @@
+package m2s
+
+import (
+	"encoding"
+	"encoding/json"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// EncodeValues converts a struct (or a pointer to a struct) into url.Values
+// using the same rules Convert uses for decoding. Nil pointers are omitted,
+// as are zero values of fields tagged with the omitempty option. File fields
+// cannot be represented in url.Values and are skipped.
+func EncodeValues(v any, opts ...Option) (url.Values, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return nil, ErrValueCannotBeNil
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return nil, ErrValueMustBeStruct
+	}
+
+	c := newConfig(opts)
+	values := make(url.Values)
+	var remaining []field
+
+	for _, f := range c.structFields(rv.Type()) {
+		if f.kind != FieldValue {
+			continue // Skip files, they can only be sent as multipart
+		}
+
+		if isRemaining(f) {
+			remaining = append(remaining, f)
+			continue
+		}
+
+		if f.typ == presenceType {
+			continue
+		}
+
+		fieldValue := rv.Field(f.index)
+
+		if f.opts.Contains("omitempty") && isEmptyValue(fieldValue) {
+			continue
+		}
+
+		if fieldValue.Kind() == reflect.Pointer && fieldValue.IsNil() {
+			continue
+		}
+
+		if o, ok := asOptional(fieldValue); ok && !o.isSet() {
+			continue
+		}
+
+		if f.opts.Contains("checkbox") && isUnchecked(fieldValue) {
+			continue // an unchecked checkbox is not sent
+		}
+
+		formValue, err := c.encodeValue(f, fieldValue)
+		if err != nil {
+			return nil, err
+		}
+		values.Set(f.key, formValue)
+	}
+
+	// catch-all values, unless a field already set the key
+	for _, f := range remaining {
+		m := rv.Field(f.index)
+		if remainingElem(f.typ) != remainingValuesType {
+			continue
+		}
+		iter := m.MapRange()
+		for iter.Next() {
+			key := iter.Key().String()
+			if _, ok := values[key]; !ok {
+				values[key] = slices.Clone(iter.Value().Interface().([]string))
+			}
+		}
+	}
+
+	return values, nil
+}
+
+func (c *config) encodeValue(f field, fieldValue reflect.Value) (string, error) {
+	if fieldValue.Kind() == reflect.Pointer {
+		if fieldValue.IsNil() {
+			return "", nil
+		}
+		return c.encodeValue(f, fieldValue.Elem())
+	}
+
+	// if m2s.Optional
+	if o, ok := asOptional(fieldValue); ok {
+		return o.encodeForm(c, f)
+	}
+
+	// if a slice with the split tag option
+	if isSplit(f) && fieldValue.Type() == indirectType(f.typ) {
+		return c.encodeSplit(f, fieldValue)
+	}
+
+	// if time.Duration or a slice of durations
+	if fieldValue.Type() == durationType {
+		return formatDuration(f, time.Duration(fieldValue.Int())), nil
+	}
+	if fieldValue.Kind() == reflect.Slice && isDurationType(fieldValue.Type()) {
+		return c.encodeArray(f, fieldValue)
+	}
+
+	// if time.Time with time options
+	if fieldValue.Type() == timeType && c.usesTimeParser(f) {
+		return c.formatTime(f, fieldValue.Interface().(time.Time)), nil
+	}
+
+	// if implements encoding.TextMarshaler
+	if m, ok := textMarshaler(fieldValue); ok {
+		text, err := m.MarshalText()
+		if err != nil {
+			return "", err
+		}
+		return string(text), nil
+	}
+
+	switch fieldValue.Kind() {
+	case reflect.String:
+		return fieldValue.String(), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(fieldValue.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(fieldValue.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return c.numberFormat(f).formatFloat(fieldValue.Float(), fieldValue.Type().Bits()), nil
+	case reflect.Bool:
+		return strconv.FormatBool(fieldValue.Bool()), nil
+	case reflect.Complex64, reflect.Complex128:
+		return strconv.FormatComplex(fieldValue.Complex(), 'f', -1, fieldValue.Type().Bits()), nil
+	case reflect.Array:
+		return c.encodeArray(f, fieldValue)
+	case reflect.Struct, reflect.Slice, reflect.Map:
+		b, err := json.Marshal(fieldValue.Interface())
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	default:
+		return "", fieldError(f, ErrInvalidFieldType)
+	}
+}
+
+func textMarshaler(rv reflect.Value) (encoding.TextMarshaler, bool) {
+	if m, ok := rv.Interface().(encoding.TextMarshaler); ok {
+		return m, true
+	}
+	if !reflect.PointerTo(rv.Type()).Implements(reflect.TypeFor[encoding.TextMarshaler]()) {
+		return nil, false
+	}
+	if !rv.CanAddr() {
+		ptrVal := reflect.New(rv.Type())
+		ptrVal.Elem().Set(rv)
+		rv = ptrVal.Elem()
+	}
+	return rv.Addr().Interface().(encoding.TextMarshaler), true
+}
+
+func isEmptyValue(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Slice, reflect.Map, reflect.String:
+		return rv.Len() == 0
+	default:
+		return rv.IsZero()
+	}
+}
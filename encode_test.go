@@ -0,0 +1,405 @@
+package m2s
+
+import (
+	"errors"
+	"mime/multipart"
+	"net/url"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEncodeValues(t *testing.T) {
+	type CustomType struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+
+	testTime := time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		v          any
+		wantValues url.Values
+		checkError func(*testing.T, error)
+	}{
+		{
+			name: "primitive types",
+			v: &struct {
+				Key1 string    `form:"key1"`
+				Key2 int32     `form:"key2"`
+				Key3 uint16    `form:"key3"`
+				Key4 float32   `form:"key4"`
+				Key5 complex64 `form:"key5"`
+				Key6 bool      `form:"key6"`
+			}{
+				Key1: "value1",
+				Key2: 42,
+				Key3: 42,
+				Key4: 42.5,
+				Key5: 10 + 11i,
+				Key6: true,
+			},
+			wantValues: url.Values{
+				"key1": {"value1"},
+				"key2": {"42"},
+				"key3": {"42"},
+				"key4": {"42.5"},
+				"key5": {"(10+11i)"},
+				"key6": {"true"},
+			},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "pointer types",
+			v: struct {
+				Key1 *string `form:"key1"`
+				Key2 *int    `form:"key2"`
+			}{
+				Key1: ptr("value1"),
+			},
+			wantValues: url.Values{
+				"key1": {"value1"},
+			},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "text marshaler",
+			v: struct {
+				Time1 time.Time  `form:"time1"`
+				Time2 *time.Time `form:"time2"`
+			}{
+				Time1: testTime,
+				Time2: &testTime,
+			},
+			wantValues: url.Values{
+				"time1": {"2024-05-06T07:08:09Z"},
+				"time2": {"2024-05-06T07:08:09Z"},
+			},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "time options",
+			v: struct {
+				Date  time.Time  `form:"date,layout=2006-01-02"`
+				Stamp *time.Time `form:"stamp,unix"`
+				Milli time.Time  `form:"milli,unixmilli"`
+			}{
+				Date:  testTime,
+				Stamp: &testTime,
+				Milli: testTime,
+			},
+			wantValues: url.Values{
+				"date":  {"2024-05-06"},
+				"stamp": {"1714979289"},
+				"milli": {"1714979289000"},
+			},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "durations",
+			v: struct {
+				Timeout   time.Duration    `form:"timeout"`
+				Retention *time.Duration   `form:"retention,unit=s"`
+				Intervals []time.Duration  `form:"intervals"`
+				Delays    []*time.Duration `form:"delays,unit=ms"`
+			}{
+				Timeout:   90 * time.Minute,
+				Retention: ptr(1500 * time.Millisecond),
+				Intervals: []time.Duration{90 * time.Second},
+				Delays:    []*time.Duration{ptr(250 * time.Millisecond), nil},
+			},
+			wantValues: url.Values{
+				"timeout":   {"1h30m0s"},
+				"retention": {"1.5"},
+				"intervals": {`["1m30s"]`},
+				"delays":    {`[250,null]`},
+			},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "checkboxes",
+			v: struct {
+				Subscribe bool  `form:"subscribe,checkbox"`
+				Terms     *bool `form:"terms,checkbox"`
+				Remember  bool  `form:"remember"`
+			}{
+				Terms: ptr(true),
+			},
+			wantValues: url.Values{
+				"terms":    {"true"},
+				"remember": {"false"},
+			},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "split values",
+			v: struct {
+				Tags []string `form:"tags,split=,"`
+				IDs  []int    `form:"ids,split=space"`
+			}{
+				Tags: []string{"go", "web"},
+				IDs:  []int{1, 2, 3},
+			},
+			wantValues: url.Values{
+				"tags": {"go,web"},
+				"ids":  {"1 2 3"},
+			},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "arrays",
+			v: struct {
+				RGB      [3]int           `form:"rgb"`
+				Size     [2]int           `form:"size,split=x"`
+				Timeouts [2]time.Duration `form:"timeouts"`
+				Delays   [2]time.Duration `form:"delays,unit=s"`
+				Names    [2]*string       `form:"names"`
+			}{
+				RGB:      [3]int{255, 128, 0},
+				Size:     [2]int{800, 600},
+				Timeouts: [2]time.Duration{90 * time.Second, time.Minute},
+				Delays:   [2]time.Duration{1500 * time.Millisecond, time.Second},
+				Names:    [2]*string{ptr("John"), nil},
+			},
+			wantValues: url.Values{
+				"rgb":      {"[255,128,0]"},
+				"size":     {"800x600"},
+				"timeouts": {`["1m30s","1m0s"]`},
+				"delays":   {"[1.5,1]"},
+				"names":    {`["John",null]`},
+			},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "remaining values",
+			v: struct {
+				Name  string              `form:"name"`
+				Extra map[string][]string `form:",remaining"`
+			}{
+				Name:  "John",
+				Extra: map[string][]string{"name": {"Jane"}, "plugin.color": {"red", "blue"}},
+			},
+			wantValues: url.Values{
+				"name":         {"John"},
+				"plugin.color": {"red", "blue"},
+			},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "json types",
+			v: &struct {
+				Custom CustomType     `form:"custom"`
+				List   []string       `form:"list"`
+				Map    map[string]int `form:"map"`
+			}{
+				Custom: CustomType{Name: "John", Age: 42},
+				List:   []string{"a", "b"},
+				Map:    map[string]int{"a": 1},
+			},
+			wantValues: url.Values{
+				"custom": {`{"name":"John","age":42}`},
+				"list":   {`["a","b"]`},
+				"map":    {`{"a":1}`},
+			},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "omitempty",
+			v: &struct {
+				Name  string   `form:"name,omitempty"`
+				Age   int      `form:"age,omitempty"`
+				List  []string `form:"list,omitempty"`
+				Count int      `form:"count"`
+			}{},
+			wantValues: url.Values{
+				"count": {"0"},
+			},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "skip unexported, ignored and file fields",
+			v: &struct {
+				name  string
+				Skip  string                `form:"-"`
+				File  *multipart.FileHeader `form:"file"`
+				Files []multipart.FileHeader
+				Name  string
+			}{
+				name: "John",
+				Skip: "skip",
+				File: &multipart.FileHeader{Filename: "file.txt"},
+				Name: "Jane",
+			},
+			wantValues: url.Values{
+				"Name": {"Jane"},
+			},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name:       "error when nil value",
+			v:          (*CustomType)(nil),
+			wantValues: nil,
+			checkError: func(t *testing.T, err error) {
+				if !errors.Is(err, ErrValueCannotBeNil) {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "number formats",
+			v: struct {
+				Price float64 `form:"price,decimal=,"`
+				Rate  float32 `form:"rate,percent"`
+				Tax   float64 `form:"tax,percent,decimal=,"`
+			}{
+				Price: 1234.56,
+				Rate:  0.07,
+				Tax:   0.195,
+			},
+			wantValues: url.Values{
+				"price": {"1234,56"},
+				"rate":  {"7"},
+				"tax":   {"19,5"},
+			},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "optional values",
+			v: struct {
+				Name     Optional[string]    `form:"name"`
+				Age      Optional[int]       `form:"age"`
+				Bio      Optional[string]    `form:"bio"`
+				Born     Optional[time.Time] `form:"born,layout=2006-01-02"`
+				Presence Presence
+			}{
+				Name:     Some(""),
+				Bio:      Optional[string]{Set: true, Null: true},
+				Born:     Some(testTime),
+				Presence: Presence{Keys: []string{"name"}},
+			},
+			wantValues: url.Values{
+				"name": {""},
+				"bio":  {"null"},
+				"born": {"2024-05-06"},
+			},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name:       "error when non-struct type",
+			v:          ptr(""),
+			wantValues: nil,
+			checkError: func(t *testing.T, err error) {
+				if !errors.Is(err, ErrValueMustBeStruct) {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "error when invalid field type",
+			v: &struct {
+				Invalid func() `form:"invalid"`
+			}{
+				Invalid: func() {},
+			},
+			wantValues: nil,
+			checkError: func(t *testing.T, err error) {
+				if !errors.Is(err, ErrInvalidFieldType) {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			values, err := EncodeValues(tt.v)
+			tt.checkError(t, err)
+			if !reflect.DeepEqual(values, tt.wantValues) {
+				t.Errorf("EncodeValues() got = %v, want %v", values, tt.wantValues)
+			}
+		})
+	}
+}
+
+func TestEncodeValuesRoundTrip(t *testing.T) {
+	type Body struct {
+		Name    string   `form:"name"`
+		Age     *int     `form:"age"`
+		Hobbies []string `form:"hobbies"`
+		Score   float64  `form:"score,omitempty"`
+	}
+
+	in := Body{Name: "John", Age: ptr(42), Hobbies: []string{"go", "chess"}, Score: 9.5}
+
+	values, err := EncodeValues(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var out Body
+	err = Convert(&multipart.Form{Value: values}, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(in, out) {
+		t.Errorf("round trip got = %+v, want %+v", out, in)
+	}
+}
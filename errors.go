@@ -1,19 +1,230 @@
 package m2s
 
-import "errors"
+import (
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+)
 
 var (
-	ErrValueMustBePointer = errors.New("value must be a pointer")
-	ErrValueCannotBeNil   = errors.New("value cannot be nil")
-	ErrValueMustBeStruct  = errors.New("value must be a struct")
-	ErrInvalidFieldType   = errors.New("invalid field type")
+	ErrValueMustBePointer = newError(CodeValueNotPointer, "value must be a pointer")
+	ErrValueCannotBeNil   = newError(CodeValueNil, "value cannot be nil")
+	ErrValueMustBeStruct  = newError(CodeValueNotStruct, "value must be a struct")
+	ErrInvalidFieldType   = newError(CodeInvalidType, "invalid field type")
+	ErrInvalidTag         = newError(CodeInvalidTag, "invalid form tag")
+	ErrEmptyValue         = newError(CodeEmptyValue, "empty value")
+	ErrDuplicateValue     = newError(CodeDuplicateValue, "key sent more than once")
+	ErrLengthMismatch     = newError(CodeLengthMismatch, "wrong number of values for array")
+)
+
+// Error codes identify an error independently of its message. Unlike error
+// messages, they are stable and safe to match on in clients.
+const (
+	CodeUnknown         = "unknown"
+	CodeValueNotPointer = "value.not_pointer"
+	CodeValueNil        = "value.nil"
+	CodeValueNotStruct  = "value.not_struct"
+	CodeInvalidType     = "type.invalid"
+	CodeInvalidTag      = "tag.invalid"
+	CodeFormInvalid     = "form.invalid"
+	CodeFormTooLarge    = "form.too_large"
+	CodeInvalidFields   = "form.invalid_fields"
+	CodeUnknownFields   = "form.unknown_fields"
+	CodeParseText       = "parse.text"
+	CodeParseInt        = "parse.int"
+	CodeParseUint       = "parse.uint"
+	CodeParseFloat      = "parse.float"
+	CodeParseBool       = "parse.bool"
+	CodeParseComplex    = "parse.complex"
+	CodeParseJSON       = "parse.json"
+	CodeParseTime       = "parse.time"
+	CodeParseDuration   = "parse.duration"
+	CodeEmptyValue      = "parse.empty"
+	CodeDuplicateValue  = "parse.duplicate"
+	CodeLengthMismatch  = "parse.length"
 )
 
+// Error is implemented by every error m2s produces. ErrorCode returns one
+// of the Code constants and ErrorParams the values a MessageCatalog may use
+// to render the message, e.g. "key" for the form key of a field.
+type Error interface {
+	error
+	ErrorCode() string
+	ErrorParams() map[string]any
+}
+
+type sentinelError struct {
+	code string
+	msg  string
+}
+
+func newError(code, msg string) error {
+	return &sentinelError{code: code, msg: msg}
+}
+
+func (e *sentinelError) Error() string {
+	return e.msg
+}
+
+func (e *sentinelError) ErrorCode() string {
+	return e.code
+}
+
+func (e *sentinelError) ErrorParams() map[string]any {
+	return nil
+}
+
+// RedactedValue replaces the raw value in ErrParseFailed, and in the
+// messages of its Err, for fields tagged with the redact option, e.g. `form:"password,redact"`.
+const RedactedValue = "[REDACTED]"
+
+// redactedError hides the raw value in the message of err, as in the one
+// of a *strconv.NumError, but still unwraps to err.
+type redactedError struct {
+	err   error
+	value string
+}
+
+func (e redactedError) Error() string {
+	msg := e.err.Error()
+	if e.value == "" {
+		return msg
+	}
+	msg = strings.ReplaceAll(msg, strconv.Quote(e.value), strconv.Quote(RedactedValue))
+	if strings.Contains(msg, e.value) {
+		return "invalid value " + RedactedValue
+	}
+	return msg
+}
+
+func (e redactedError) Unwrap() error {
+	return e.err
+}
+
+// ErrParseFailed is returned when a form value cannot be converted into the
+// type of its struct field. Err is the underlying error, e.g. a
+// *strconv.NumError or a *time.ParseError, and can be inspected with
+// errors.Is and errors.As.
 type ErrParseFailed struct {
-	Field string
-	Err   error
+	Field     string       // Go name of the struct field
+	Key       string       // form key the value was read from
+	FieldPath string       // path of the field, e.g. "Tags[2]" for an element
+	Value     string       // raw form value, or RedactedValue
+	Type      reflect.Type // Go type the value was converted to
+	Code      string       // one of the Code constants
+	Err       error
 }
 
 func (e ErrParseFailed) Error() string {
 	return "failed to parse field " + e.Field + ": " + e.Err.Error()
 }
+
+func (e ErrParseFailed) Unwrap() error {
+	return e.Err
+}
+
+func (e ErrParseFailed) ErrorCode() string {
+	return e.Code
+}
+
+func (e ErrParseFailed) ErrorParams() map[string]any {
+	return map[string]any{
+		"field":  e.Field,
+		"key":    e.Key,
+		"path":   e.FieldPath,
+		"reason": e.Err.Error(),
+	}
+}
+
+func (e ErrParseFailed) formKey() string {
+	return e.Key
+}
+
+// ErrField wraps an error that relates to a specific struct field, such as
+// ErrInvalidFieldType.
+type ErrField struct {
+	Field     string       // Go name of the struct field
+	Key       string       // form key of the field
+	FieldPath string       // path of the field
+	Type      reflect.Type // Go type of the field
+	Err       error
+}
+
+func (e ErrField) Error() string {
+	return "field " + e.Field + ": " + e.Err.Error()
+}
+
+func (e ErrField) Unwrap() error {
+	return e.Err
+}
+
+func (e ErrField) ErrorCode() string {
+	return ErrorCode(e.Err)
+}
+
+func (e ErrField) ErrorParams() map[string]any {
+	params := map[string]any{
+		"field": e.Field,
+		"key":   e.Key,
+		"path":  e.FieldPath,
+	}
+	if e.Type != nil {
+		params["type"] = e.Type.String()
+	}
+	return params
+}
+
+func (e ErrField) formKey() string {
+	return e.Key
+}
+
+// keyedError is implemented by errors that relate to a single form key.
+type keyedError interface {
+	error
+	formKey() string
+}
+
+// ErrorCode returns the code of err. Besides errors implementing Error, it
+// recognizes the errors net/http and mime/multipart return for malformed or
+// too large forms. It returns CodeUnknown for any other error.
+func ErrorCode(err error) string {
+	var merr Error
+	var maxBytesErr *http.MaxBytesError
+	switch {
+	case errors.As(err, &merr):
+		return merr.ErrorCode()
+	case errors.As(err, &maxBytesErr), errors.Is(err, multipart.ErrMessageTooLarge):
+		return CodeFormTooLarge
+	case errors.Is(err, http.ErrNotMultipart), errors.Is(err, http.ErrMissingBoundary):
+		return CodeFormInvalid
+	default:
+		return CodeUnknown
+	}
+}
+
+// errorParams returns the message parameters of err, if it carries any.
+func errorParams(err error) map[string]any {
+	var merr Error
+	if errors.As(err, &merr) {
+		return merr.ErrorParams()
+	}
+	return nil
+}
+
+// flattenErrors returns the errors combined in err by errors.Join, or err
+// itself if it is not a joined error.
+func flattenErrors(err error) []error {
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return []error{err}
+	}
+
+	var errs []error
+	for _, err := range joined.Unwrap() {
+		errs = append(errs, flattenErrors(err)...)
+	}
+	return errs
+}
@@ -1,7 +1,6 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
 	"mime/multipart"
 	"net/http"
@@ -10,7 +9,7 @@ import (
 )
 
 func main() {
-	http.HandleFunc("POST /", rootPage)
+	http.Handle("POST /", m2s.Handler(rootPage))
 
 	fmt.Println("Listening on port 8080")
 	err := http.ListenAndServe(":8080", nil)
@@ -26,24 +25,7 @@ type ReqBody struct {
 	File    *multipart.FileHeader `form:"file"`
 }
 
-func rootPage(w http.ResponseWriter, r *http.Request) {
-	err := r.ParseMultipartForm(32 << 20)
-	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		return
-	}
-
-	mpf := r.MultipartForm
-
-	reqBody := &ReqBody{}
-
-	err = m2s.Convert(mpf, reqBody)
-	if err != nil {
-		w.WriteHeader(http.StatusBadRequest)
-		json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
-		return
-	}
-
+func rootPage(w http.ResponseWriter, r *http.Request, reqBody ReqBody) {
 	fmt.Printf("reqBody: %+v\n", reqBody)
 }
 
@@ -0,0 +1,103 @@
+package m2s
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// DefaultMaxMemory is the number of bytes of file parts Handler keeps in
+// memory while parsing a multipart form unless WithMaxMemory is given.
+const DefaultMaxMemory = 32 << 20
+
+// ErrorHandler writes the response for a request whose multipart form could
+// not be parsed or converted.
+type ErrorHandler func(w http.ResponseWriter, r *http.Request, err error)
+
+// HandlerOption configures a handler returned by Handler.
+type HandlerOption func(*handlerConfig)
+
+type handlerConfig struct {
+	maxMemory    int64
+	errorHandler ErrorHandler
+	opts         []Option
+}
+
+// WithMaxMemory sets the number of bytes of file parts kept in memory while
+// parsing the multipart form. The rest is stored in temporary files.
+func WithMaxMemory(maxMemory int64) HandlerOption {
+	return func(c *handlerConfig) {
+		c.maxMemory = maxMemory
+	}
+}
+
+// WithErrorHandler replaces the default error response, which is a
+// 400 Bad Request with a JSON list of error messages.
+func WithErrorHandler(h ErrorHandler) HandlerOption {
+	return func(c *handlerConfig) {
+		c.errorHandler = h
+	}
+}
+
+// WithOptions sets the options the handler decodes request bodies with.
+func WithOptions(opts ...Option) HandlerOption {
+	return func(c *handlerConfig) {
+		c.opts = append(c.opts, opts...)
+	}
+}
+
+// Handler returns an http.Handler that parses the multipart form of each
+// request, converts it into a value of type T and calls fn with it. If
+// parsing or converting fails, the error handler is called instead. The
+// temporary files of the form are removed after the request is handled.
+//
+// Handler panics if T cannot be used with NewDecoder, so a wrong body type
+// is reported when the route is registered.
+func Handler[T any](fn func(w http.ResponseWriter, r *http.Request, body T), opts ...HandlerOption) http.Handler {
+	cfg := handlerConfig{
+		maxMemory:    DefaultMaxMemory,
+		errorHandler: writeErrors,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	d, err := NewDecoder[T](cfg.opts...)
+	if err != nil {
+		panic("m2s: " + err.Error())
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		err := r.ParseMultipartForm(cfg.maxMemory)
+		if r.MultipartForm != nil {
+			defer r.MultipartForm.RemoveAll()
+		}
+		if err != nil {
+			cfg.errorHandler(w, r, err)
+			return
+		}
+
+		body, err := d.Decode(r.MultipartForm)
+		if err != nil {
+			cfg.errorHandler(w, r, err)
+			return
+		}
+
+		fn(w, r, body)
+	})
+}
+
+func writeErrors(w http.ResponseWriter, _ *http.Request, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(map[string][]string{"errors": errorMessages(err)})
+}
+
+// errorMessages returns the messages of err, one per error if err was
+// created by errors.Join.
+func errorMessages(err error) []string {
+	var messages []string
+	for _, err := range flattenErrors(err) {
+		messages = append(messages, err.Error())
+	}
+	return messages
+}
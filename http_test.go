@@ -0,0 +1,148 @@
+package m2s
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func newMultipartRequest(t *testing.T, fields map[string]string, files map[string]string) *http.Request {
+	t.Helper()
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	for k, v := range fields {
+		err := mw.WriteField(k, v)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for k, v := range files {
+		fw, err := mw.CreateFormFile(k, k+".txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = fw.Write([]byte(v))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := mw.Close()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := httptest.NewRequest(http.MethodPost, "/", &buf)
+	r.Header.Set("Content-Type", mw.FormDataContentType())
+	return r
+}
+
+func TestHandler(t *testing.T) {
+	type Body struct {
+		Name string                `form:"name"`
+		Age  int                   `form:"age"`
+		File *multipart.FileHeader `form:"file"`
+	}
+
+	t.Run("calls handler with body", func(t *testing.T) {
+		var got Body
+		h := Handler(func(w http.ResponseWriter, r *http.Request, body Body) {
+			got = body
+			w.WriteHeader(http.StatusCreated)
+		})
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, newMultipartRequest(t, map[string]string{"name": "John", "age": "42"}, map[string]string{"file": "content"}))
+
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("status got = %d, want %d", rec.Code, http.StatusCreated)
+		}
+		if got.Name != "John" || got.Age != 42 || got.File == nil || got.File.Filename != "file.txt" {
+			t.Errorf("body got = %+v", got)
+		}
+	})
+
+	t.Run("default error response", func(t *testing.T) {
+		called := false
+		h := Handler(func(w http.ResponseWriter, r *http.Request, body Body) {
+			called = true
+		})
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, newMultipartRequest(t, map[string]string{"age": "invalid"}, nil))
+
+		if called {
+			t.Fatal("handler must not be called")
+		}
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("status got = %d, want %d", rec.Code, http.StatusBadRequest)
+		}
+		if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+			t.Errorf("content type got = %q", ct)
+		}
+
+		var resp struct {
+			Errors []string `json:"errors"`
+		}
+		err := json.NewDecoder(rec.Body).Decode(&resp)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.Errors) != 1 {
+			t.Errorf("errors got = %v", resp.Errors)
+		}
+	})
+
+	t.Run("custom error handler", func(t *testing.T) {
+		var gotErr error
+		h := Handler(func(w http.ResponseWriter, r *http.Request, body Body) {}, WithErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) {
+			gotErr = err
+			w.WriteHeader(http.StatusUnprocessableEntity)
+		}))
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, newMultipartRequest(t, map[string]string{"age": "invalid"}, nil))
+
+		if rec.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("status got = %d, want %d", rec.Code, http.StatusUnprocessableEntity)
+		}
+		var terr ErrParseFailed
+		if !errors.As(gotErr, &terr) {
+			t.Errorf("unexpected error: %v", gotErr)
+		}
+	})
+
+	t.Run("error when request is not multipart", func(t *testing.T) {
+		h := Handler(func(w http.ResponseWriter, r *http.Request, body Body) {}, WithMaxMemory(1<<10))
+
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/", nil))
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("status got = %d, want %d", rec.Code, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("panics when body type is invalid", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected panic")
+			}
+		}()
+		Handler(func(w http.ResponseWriter, r *http.Request, body string) {})
+	})
+}
+
+func TestErrorMessages(t *testing.T) {
+	err := errors.Join(errors.New("a"), errors.Join(errors.New("b"), errors.New("c")))
+	got := errorMessages(err)
+	want := []string{"a", "b", "c"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("errorMessages() got = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,98 @@
+package tags
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// MultiPolicies lists the values of the multi option.
+var MultiPolicies = []string{"nonempty", "first", "last", "join", "error"}
+
+// DurationUnits lists the values of the unit option.
+var DurationUnits = []string{"ns", "us", "µs", "ms", "s", "m", "h"}
+
+// Modifiers lists the modifiers of the mod tag available without
+// m2s.WithModifier.
+var Modifiers = []string{"trim", "lower", "upper", "collapse", "strip_control"}
+
+// Type describes the type of a struct field as far as Check needs it. m2s
+// fills it from reflect and the analyzer from go/types, so that both apply
+// the same rules.
+type Type struct {
+	Time      bool // time.Time or a pointer to it
+	Bool      bool // bool or a pointer to it
+	Duration  bool // time.Duration, a pointer to it or a slice or array of either
+	List      bool // slice or array bound from values, not files
+	Integer   bool // integer, a pointer to one or a slice or array of either
+	Float     bool // float, a pointer to one or a slice or array of either
+	Remaining bool // map[string][]string or map[string][]*multipart.FileHeader
+}
+
+// Check reports every option of opts that is malformed or does not apply
+// to a field of type t. Only the first malformed option is reported, since
+// the others cannot be interpreted.
+func Check(opts Options, t Type) []error {
+	err := opts.Validate()
+	if err != nil {
+		return []error{err}
+	}
+
+	var errs []error
+	requires := func(opt, what string) {
+		errs = append(errs, fmt.Errorf("tag option %q requires %s", opt, what))
+	}
+	for _, opt := range TimeOptions {
+		if opts.Contains(opt) && !t.Time {
+			requires(opt, "a time.Time field")
+		}
+	}
+	if name, ok := opts.Lookup("multi"); ok && !slices.Contains(MultiPolicies, name) {
+		errs = append(errs, fmt.Errorf("unknown multi-value policy %q", name))
+	}
+	for _, opt := range SliceOptions {
+		if opts.Contains(opt) && !t.List {
+			requires(opt, "a slice or array field")
+		}
+	}
+	if opts.Contains("trim") && !opts.Contains("split") {
+		requires("trim", "the split option")
+	}
+	for _, opt := range BoolOptions {
+		if opts.Contains(opt) && !t.Bool {
+			requires(opt, "a bool field")
+		}
+	}
+	for _, opt := range NumberOptions {
+		if opts.Contains(opt) && !t.Integer && !t.Float {
+			requires(opt, "a number field")
+		}
+	}
+	if opts.Contains("prefix") && !t.Integer {
+		requires("prefix", "an integer field")
+	}
+	if opts.Contains("percent") && !t.Float {
+		requires("percent", "a float field")
+	}
+	if opts.Contains("currency") && opts.Contains("prefix") && t.Integer {
+		// the letters of a currency code could be hex digits
+		errs = append(errs, fmt.Errorf("tag options %q and %q cannot be combined", "currency", "prefix"))
+	}
+	if group, ok := opts.Lookup("group"); ok && (t.Integer || t.Float) {
+		decimal, _ := opts.Lookup("decimal")
+		if group == cmp.Or(decimal, ".") {
+			errs = append(errs, fmt.Errorf("decimal and group separators are both %q", group))
+		}
+	}
+	if unit, ok := opts.Lookup("unit"); ok {
+		if !t.Duration {
+			requires("unit", "a time.Duration field")
+		} else if !slices.Contains(DurationUnits, unit) {
+			errs = append(errs, fmt.Errorf("unknown duration unit %q", unit))
+		}
+	}
+	if opts.Contains("remaining") && !t.Remaining {
+		requires("remaining", "a map[string][]string or map[string][]*multipart.FileHeader field")
+	}
+	return errs
+}
@@ -0,0 +1,149 @@
+// Package tags parses form struct tags. It is shared by m2s and its vet
+// analyzer, so both agree on which tag options exist.
+package tags
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+)
+
+// Known maps the options m2s understands to whether they take a value, as
+// in `layout=2006-01-02`.
+var Known = map[string]bool{
+	"omitempty": false, // skip zero values when encoding
+	"redact":    false, // keep the raw value out of errors
+	"layout":    true,  // time layouts, separated by |
+	"unix":      false, // time as Unix seconds
+	"unixmilli": false, // time as Unix milliseconds
+	"unit":      true,  // unit of bare numbers in durations, e.g. s
+	"checkbox":  false, // absent bool means false
+	"multi":     true,  // multi-value policy, e.g. last
+	"split":     true,  // separator of slice elements, e.g. , or space
+	"trim":      false, // trim whitespace around split elements
+	"remaining": false, // collect keys no other field uses
+	"alias":     true,  // alternative keys, separated by |
+
+	"decimal":     true,  // decimal separator of numbers, e.g. ,
+	"group":       true,  // grouping separator of numbers, e.g. . or space
+	"underscores": false, // allow underscores between digits
+	"prefix":      false, // allow 0x, 0o and 0b prefixes on integers
+	"percent":     false, // float as a percentage, e.g. 12.5%
+	"currency":    false, // strip currency symbols and codes
+}
+
+// TimeOptions lists the options that only apply to time.Time fields.
+var TimeOptions = []string{"layout", "unix", "unixmilli"}
+
+// BoolOptions lists the options that only apply to bool fields.
+var BoolOptions = []string{"checkbox"}
+
+// SliceOptions lists the options that only apply to slice fields.
+var SliceOptions = []string{"split", "trim"}
+
+// DurationOptions lists the options that only apply to time.Duration
+// fields and slices or arrays of them.
+var DurationOptions = []string{"unit"}
+
+// NumberOptions lists the options that only apply to number fields and
+// slices or arrays of them.
+var NumberOptions = []string{"decimal", "group", "underscores", "prefix", "percent", "currency"}
+
+// Get returns the tag of a struct field to parse: the value of the key tag
+// or, if it is absent and jsonFallback is set, the name part of the json
+// tag. It returns "-" if the field is ignored.
+func Get(st reflect.StructTag, key string, jsonFallback bool) string {
+	if tag, ok := st.Lookup(key); ok || !jsonFallback {
+		return tag
+	}
+	tag, ok := st.Lookup("json")
+	if !ok || tag == "-" {
+		return tag
+	}
+	name, _, _ := strings.Cut(tag, ",")
+	return name + "," // keeps `json:"-,"`, which means the key "-"
+}
+
+// Keys splits the name part of a form tag and the alias option into the
+// key of a field and its aliases, e.g. `form:"email|mail,alias=e_mail"`
+// into "email" and "mail", "e_mail". The key is empty if the tag has no
+// name.
+func Keys(name string, opts Options) (string, []string) {
+	key, rest, ok := strings.Cut(name, "|")
+	var aliases []string
+	if ok {
+		aliases = strings.Split(rest, "|")
+	}
+	if alias, ok := opts.Lookup("alias"); ok {
+		aliases = append(aliases, strings.Split(alias, "|")...)
+	}
+	return key, aliases
+}
+
+// Options is the comma-separated list of options that follows the key in a
+// form tag, e.g. "omitempty" in `form:"name,omitempty"`.
+type Options string
+
+// Parse splits a form tag into its key and its options.
+func Parse(tag string) (string, Options) {
+	name, opts, _ := strings.Cut(tag, ",")
+	return name, Options(opts)
+}
+
+// Contains reports whether the option list contains the given option.
+func (o Options) Contains(option string) bool {
+	_, ok := o.Lookup(option)
+	return ok
+}
+
+// Lookup returns the value of the given option and whether it is present.
+// Options without a value have an empty value.
+func (o Options) Lookup(option string) (string, bool) {
+	for _, opt := range o.List() {
+		name, value, _ := strings.Cut(opt, "=")
+		if name == option {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// List returns the options as a slice, or nil if there are none. A comma
+// right after "=" is the value of that option, so `split=,` is one option.
+func (o Options) List() []string {
+	if o == "" {
+		return nil
+	}
+	segments := strings.Split(string(o), ",")
+	opts := make([]string, 0, len(segments))
+	for i := 0; i < len(segments); i++ {
+		opt := segments[i]
+		if strings.HasSuffix(opt, "=") && i+1 < len(segments) && segments[i+1] == "" {
+			opt += ","
+			i++
+		}
+		opts = append(opts, opt)
+	}
+	return opts
+}
+
+// Validate reports the first empty or unknown option, or an option whose
+// value does not match Known.
+func (o Options) Validate() error {
+	for _, opt := range o.List() {
+		if opt == "" {
+			return errors.New("empty tag option")
+		}
+		name, value, hasValue := strings.Cut(opt, "=")
+		takesValue, ok := Known[name]
+		switch {
+		case !ok:
+			return errors.New("unknown tag option " + `"` + name + `"`)
+		case takesValue && value == "":
+			return errors.New("tag option " + `"` + name + `"` + " requires a value")
+		case !takesValue && hasValue:
+			return errors.New("tag option " + `"` + name + `"` + " takes no value")
+		}
+	}
+	return nil
+}
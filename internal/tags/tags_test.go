@@ -0,0 +1,150 @@
+package tags
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	name, opts := Parse("name,omitempty,redact")
+	if name != "name" {
+		t.Errorf("Parse() name got = %q", name)
+	}
+	if !opts.Contains("omitempty") || !opts.Contains("redact") || opts.Contains("name") {
+		t.Errorf("Parse() options got = %q", opts)
+	}
+	if got, want := opts.List(), []string{"omitempty", "redact"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("List() got = %v, want %v", got, want)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		tag     string
+		wantErr bool
+	}{
+		{"name", false},
+		{"name,omitempty", false},
+		{",redact", false},
+		{"name,", false},
+		{"name,unknown", true},
+		{"born,layout=2006-01-02", false},
+		{"born,layout", true},
+		{"born,layout=", true},
+		{"born,unix=1", true},
+		{"tags,split=,", false},
+		{"tags,split=,,trim", false},
+		{"tags,split=space,trim", false},
+		{"tags,split", true},
+		{"tags,,trim", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			_, opts := Parse(tt.tag)
+			if err := opts.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestLookup(t *testing.T) {
+	_, opts := Parse("born,layout=2006-01-02|15:04,redact")
+
+	layout, ok := opts.Lookup("layout")
+	if !ok || layout != "2006-01-02|15:04" {
+		t.Errorf("Lookup() got = %q, %v", layout, ok)
+	}
+	if _, ok := opts.Lookup("unix"); ok {
+		t.Error("Lookup() found missing option")
+	}
+	if !opts.Contains("layout") || !opts.Contains("redact") {
+		t.Error("Contains() did not find option")
+	}
+}
+
+func TestListCommaValue(t *testing.T) {
+	_, opts := Parse("tags,split=,,trim")
+
+	if got, want := opts.List(), []string{"split=,", "trim"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("List() got = %v, want %v", got, want)
+	}
+	if sep, ok := opts.Lookup("split"); !ok || sep != "," {
+		t.Errorf("Lookup() got = %q, %v", sep, ok)
+	}
+}
+
+func TestGet(t *testing.T) {
+	tests := []struct {
+		tag          reflect.StructTag
+		jsonFallback bool
+		want         string
+	}{
+		{`form:"name,omitempty" json:"other"`, true, "name,omitempty"},
+		{`json:"name,omitempty"`, false, ""},
+		{`json:"name,omitempty"`, true, "name,"},
+		{`json:"-"`, true, "-"},
+		{`json:"-,"`, true, "-,"},
+		{`form:"" json:"name"`, true, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.tag), func(t *testing.T) {
+			if got := Get(tt.tag, "form", tt.jsonFallback); got != tt.want {
+				t.Errorf("Get() got = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeys(t *testing.T) {
+	key, aliases := Keys(Parse("email|mail,alias=e_mail|email_address"))
+	if key != "email" || !reflect.DeepEqual(aliases, []string{"mail", "e_mail", "email_address"}) {
+		t.Errorf("Keys() got = %q, %v", key, aliases)
+	}
+
+	key, aliases = Keys(Parse(",omitempty"))
+	if key != "" || aliases != nil {
+		t.Errorf("Keys() got = %q, %v", key, aliases)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		opts    Options
+		typ     Type
+		wantErr int
+	}{
+		{"layout=2006-01-02", Type{Time: true}, 0},
+		{"unix", Type{}, 1},
+		{"unknown,unix", Type{}, 1},
+		{"multi=last", Type{}, 0},
+		{"multi=newest", Type{}, 1},
+		{"split=,,trim", Type{List: true}, 0},
+		{"trim", Type{List: true}, 1},
+		{"trim", Type{}, 2},
+		{"checkbox", Type{Bool: true}, 0},
+		{"decimal=,,group=.", Type{Float: true}, 0},
+		{"group=.", Type{Float: true}, 1},
+		{"group=.", Type{Integer: true}, 1},
+		{"decimal=,,group=.", Type{Integer: true}, 0},
+		{"percent", Type{Integer: true}, 1},
+		{"prefix", Type{Float: true}, 1},
+		{"prefix,currency", Type{Integer: true}, 1},
+		{"currency", Type{}, 1},
+		{"unit=s", Type{Duration: true}, 0},
+		{"unit=d", Type{Duration: true}, 1},
+		{"unit=s", Type{Integer: true}, 1},
+		{"remaining", Type{Remaining: true}, 0},
+		{"remaining", Type{}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.opts), func(t *testing.T) {
+			if errs := Check(tt.opts, tt.typ); len(errs) != tt.wantErr {
+				t.Errorf("Check() got = %v, want %d errors", errs, tt.wantErr)
+			}
+		})
+	}
+}
@@ -4,21 +4,61 @@ import (
 	"cmp"
 	"encoding"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"mime/multipart"
 	"reflect"
+	"slices"
 	"strconv"
 	"unsafe"
+
+	"github.com/ksckaan1/m2s/internal/tags"
 )
 
-type fieldType uint
+// FieldKind tells whether a struct field is bound to a form value, a single
+// form file or multiple form files.
+type FieldKind uint
 
 const (
-	value fieldType = iota
-	file
-	files
+	FieldValue FieldKind = iota
+	FieldFile
+	FieldFiles
 )
 
-func Convert(mpf *multipart.Form, v any) error {
+func (k FieldKind) String() string {
+	switch k {
+	case FieldValue:
+		return "value"
+	case FieldFile:
+		return "file"
+	case FieldFiles:
+		return "files"
+	default:
+		return "FieldKind(" + strconv.Itoa(int(k)) + ")"
+	}
+}
+
+// field describes how a single exported struct field is bound to a form key.
+type field struct {
+	index int
+	name  string
+	path  string
+	key   string
+	typ   reflect.Type
+
+	aliases []string
+	kind    FieldKind
+	opts    tags.Options
+	mods    []string
+}
+
+// elem returns f for its element at index i, whose path ends in "[i]".
+func (f field) elem(i int) field {
+	f.path += "[" + strconv.Itoa(i) + "]"
+	return f
+}
+
+func Convert(mpf *multipart.Form, v any, opts ...Option) error {
 	rv := reflect.ValueOf(v)
 
 	err := validate(rv)
@@ -28,50 +68,174 @@ func Convert(mpf *multipart.Form, v any) error {
 
 	rv = rv.Elem()
 
-	for i := range rv.NumField() {
-		fieldType := rv.Type().Field(i)
-		fieldValue := rv.Field(i)
-		tag := fieldType.Tag.Get("form")
-		if !fieldType.IsExported() || tag == "-" {
-			continue // Skip if struct field is unexported or ignored (-)
+	c := newConfig(opts)
+	fields := c.structFields(rv.Type())
+	for _, f := range fields {
+		err = c.validateTag(f)
+		if err != nil {
+			return err
 		}
+	}
 
-		fieldName := cmp.Or(tag, fieldType.Name)
-
-		ft := determineFieldType(fieldType.Type)
-
-		if ft == file || ft == files {
-			formFiles, ok := mpf.File[fieldName]
-			if !ok || len(formFiles) == 0 {
-				continue
-			}
+	return c.decode(mpf, rv, fields)
+}
 
-			// if single file
-			if ft == file {
-				setFile(fieldType.Type, fieldValue, formFiles[0])
-				continue
-			}
+func (c *config) decode(mpf *multipart.Form, rv reflect.Value, fields []field) error {
+	if c.caseInsensitive {
+		mpf = foldForm(mpf, fields)
+	}
+	if slices.ContainsFunc(fields, func(f field) bool { return len(f.aliases) > 0 }) {
+		mpf = c.resolveAliases(mpf, fields)
+	}
 
-			// if multiple files
-			setFiles(formFiles, fieldType.Type, fieldValue)
-			continue
+	var presence Presence
+	var errs []error
+	for _, f := range fields {
+		fieldValue := rv.Field(f.index)
+		if f.typ == presenceType {
+			continue // filled below
+		}
+		if isRemaining(f) {
+			c.resetField(fieldValue)
+			continue // filled below
 		}
 
-		formValues, ok := mpf.Value[fieldName]
-		if !ok || len(formValues) == 0 {
+		prev := c.previousElems(fieldValue)
+		bound, err := c.decodeField(mpf, fieldValue, f)
+		if perr := (ErrParseFailed{}); errors.As(err, &perr) {
+			// collected, so that every invalid field is reported at once
+			errs = append(errs, err)
 			continue
 		}
-
-		// if value
-		err = convertValue(fieldType.Type, fieldValue, fieldType.Name, cmp.Or(formValues...))
 		if err != nil {
 			return err
 		}
+		if !bound {
+			c.resetField(fieldValue)
+			continue
+		}
+		appendElems(fieldValue, prev)
+		presence.add(f)
 	}
 
+	err := c.checkUnknownFields(mpf, rv, fields, &presence)
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) == 1 {
+		return errs[0]
+	}
+	if len(errs) > 1 {
+		return errors.Join(errs...)
+	}
+
+	setPresence(rv, fields, presence)
 	return nil
 }
 
+// decodeField sets a single field from mpf and reports whether it was set.
+func (c *config) decodeField(mpf *multipart.Form, fieldValue reflect.Value, f field) (bool, error) {
+	if f.kind == FieldFile || f.kind == FieldFiles {
+		formFiles, ok := mpf.File[f.key]
+		if !ok || len(formFiles) == 0 {
+			return false, nil
+		}
+
+		// if single file
+		if f.kind == FieldFile {
+			setFile(f.typ, fieldValue, formFiles[0])
+			return true, nil
+		}
+
+		// if multiple files
+		setFiles(formFiles, f.typ, fieldValue)
+		return true, nil
+	}
+
+	// if array sent as repeated or indexed keys
+	if af := f.withoutOptional(); isArrayType(af.typ) {
+		elems, ok, err := arrayValues(mpf, af)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			elems, err = c.modifyAll(af, elems)
+			if err != nil {
+				return false, err
+			}
+			arr := reflect.New(af.typ).Elem()
+			err = c.convertArrayValues(af, arr, elems)
+			if err != nil {
+				return true, err
+			}
+			if o, ok := asOptional(fieldValue); ok {
+				o.setZero()
+				fieldValue = o.value()
+			}
+			fieldValue.Set(arr)
+			return true, nil
+		}
+	}
+
+	formValues, ok := mpf.Value[f.key]
+	if !ok || len(formValues) == 0 {
+		if f.opts.Contains("checkbox") {
+			// an unchecked checkbox is not sent at all
+			return true, c.convertValue(f.typ, fieldValue, f, "false")
+		}
+		return false, nil
+	}
+
+	// if value
+	formValues, err := c.modifyAll(f, formValues)
+	if err != nil {
+		return false, err
+	}
+	formValue, err := c.selectValue(f, formValues)
+	if err != nil {
+		return false, err
+	}
+	if formValue == "" && c.emptyPolicy == EmptyAsAbsent {
+		return false, nil
+	}
+	handled, err := c.convertSpecial(f, fieldValue, formValue)
+	if err != nil || handled {
+		return true, err
+	}
+	return true, c.convertValue(f.typ, fieldValue, f, formValue)
+}
+
+// structFields returns the fields of rt Convert binds. Fields without a
+// name in their tag get a key derived from their Go name by c.keyName.
+func (c *config) structFields(rt reflect.Type) []field {
+	fields := make([]field, 0, rt.NumField())
+
+	for i := range rt.NumField() {
+		fieldType := rt.Field(i)
+		tag := tags.Get(fieldType.Tag, c.tagName, c.jsonFallback)
+		if !fieldType.IsExported() || tag == "-" {
+			continue // Skip if struct field is unexported or ignored (-)
+		}
+
+		name, opts := tags.Parse(tag)
+		key, aliases := tags.Keys(name, opts)
+
+		fields = append(fields, field{
+			index:   i,
+			name:    fieldType.Name,
+			path:    fieldType.Name,
+			key:     cmp.Or(key, c.keyName(fieldType.Name)),
+			typ:     fieldType.Type,
+			kind:    determineFieldType(fieldType.Type),
+			opts:    opts,
+			aliases: aliases,
+			mods:    modifierNames(fieldType.Tag.Get("mod")),
+		})
+	}
+
+	return fields
+}
+
 func setFile(fieldType reflect.Type, fieldValue reflect.Value, formFile *multipart.FileHeader) {
 	if fieldType.Kind() != reflect.Pointer {
 		fieldValue.Set(reflect.ValueOf(formFile).Elem())
@@ -94,14 +258,47 @@ func setFiles(formFiles []*multipart.FileHeader, fieldType reflect.Type, fieldVa
 	fieldValue.Set(list)
 }
 
-func convertValue(fieldType reflect.Type, fieldValue reflect.Value, fieldName, formValue string) error {
+func (c *config) convertValue(fieldType reflect.Type, fieldValue reflect.Value, f field, formValue string) error {
+	// if time.Time with time options
+	if fieldType == timeType && c.usesTimeParser(f) {
+		t, err := c.parseTime(f, formValue)
+		if err != nil {
+			return parseError(f, fieldType, CodeParseTime, formValue, err)
+		}
+		fieldValue.Set(reflect.ValueOf(t))
+		return nil
+	}
+
+	// if m2s.Optional
+	if optionalElem(fieldType) != nil {
+		return fieldValue.Addr().Interface().(optional).decodeForm(c, f, formValue)
+	}
+
+	// if a slice with the split tag option
+	if fieldType.Kind() == reflect.Slice && isSplit(f) && fieldType == indirectType(f.typ) {
+		return c.convertSplit(fieldType, fieldValue, f, formValue)
+	}
+
+	// if time.Duration or a slice of durations
+	if fieldType == durationType {
+		d, err := parseDuration(f, formValue)
+		if err != nil {
+			return parseError(f, fieldType, CodeParseDuration, formValue, err)
+		}
+		fieldValue.SetInt(int64(d))
+		return nil
+	}
+	if fieldType.Kind() == reflect.Slice && isDurationType(fieldType) {
+		return c.convertDurations(fieldType, fieldValue, f, formValue)
+	}
+
 	// if implements encoding.TextUnmarshaler
 	if reflect.PointerTo(fieldType).Implements(reflect.TypeFor[encoding.TextUnmarshaler]()) {
 		ptrVal := reflect.New(fieldType)
 		result := ptrVal.MethodByName("UnmarshalText").
 			Call([]reflect.Value{reflect.ValueOf(string2ByteSlice(formValue))})
 		if !result[0].IsNil() {
-			return ErrParseFailed{fieldName, result[0].Interface().(error)}
+			return parseError(f, fieldType, CodeParseText, formValue, result[0].Interface().(error))
 		}
 		fieldValue.Set(ptrVal.Elem())
 		return nil
@@ -110,7 +307,7 @@ func convertValue(fieldType reflect.Type, fieldValue reflect.Value, fieldName, f
 	switch fieldType.Kind() {
 	case reflect.Pointer:
 		v := reflect.New(fieldType.Elem())
-		err := convertValue(fieldType.Elem(), v.Elem(), fieldName, formValue)
+		err := c.convertValue(fieldType.Elem(), v.Elem(), f, formValue)
 		if err != nil {
 			return err
 		}
@@ -118,46 +315,203 @@ func convertValue(fieldType reflect.Type, fieldValue reflect.Value, fieldName, f
 	case reflect.String:
 		fieldValue.SetString(formValue)
 	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-		v, err := strconv.ParseInt(formValue, 10, 64)
+		s, base, err := c.numberFormat(f).normalize(formValue, true)
+		if err != nil {
+			return parseError(f, fieldType, CodeParseInt, formValue, err)
+		}
+		v, err := strconv.ParseInt(s, base, fieldType.Bits())
 		if err != nil {
-			return ErrParseFailed{Field: fieldName, Err: err}
+			return parseError(f, fieldType, CodeParseInt, formValue, err)
 		}
 		fieldValue.SetInt(v)
 	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-		v, err := strconv.ParseUint(formValue, 10, 64)
+		s, base, err := c.numberFormat(f).normalize(formValue, true)
+		if err != nil {
+			return parseError(f, fieldType, CodeParseUint, formValue, err)
+		}
+		v, err := strconv.ParseUint(s, base, fieldType.Bits())
 		if err != nil {
-			return ErrParseFailed{Field: fieldName, Err: err}
+			return parseError(f, fieldType, CodeParseUint, formValue, err)
 		}
 		fieldValue.SetUint(v)
 	case reflect.Float32, reflect.Float64:
-		v, err := strconv.ParseFloat(formValue, 64)
+		nf := c.numberFormat(f)
+		s, _, err := nf.normalize(formValue, false)
 		if err != nil {
-			return ErrParseFailed{Field: fieldName, Err: err}
+			return parseError(f, fieldType, CodeParseFloat, formValue, err)
+		}
+		v, err := strconv.ParseFloat(s, fieldType.Bits())
+		if err != nil {
+			return parseError(f, fieldType, CodeParseFloat, formValue, err)
+		}
+		if nf.Percent {
+			v /= 100
 		}
 		fieldValue.SetFloat(v)
 	case reflect.Bool:
-		v, err := strconv.ParseBool(formValue)
+		v, err := c.parseBool(formValue)
 		if err != nil {
-			return ErrParseFailed{Field: fieldName, Err: err}
+			return parseError(f, fieldType, CodeParseBool, formValue, err)
 		}
 		fieldValue.SetBool(v)
 	case reflect.Complex64, reflect.Complex128:
-		v, err := strconv.ParseComplex(formValue, 64)
+		v, err := strconv.ParseComplex(formValue, fieldType.Bits())
 		if err != nil {
-			return ErrParseFailed{Field: fieldName, Err: err}
+			return parseError(f, fieldType, CodeParseComplex, formValue, err)
 		}
 		fieldValue.SetComplex(v)
+	case reflect.Array:
+		return c.convertArray(fieldType, fieldValue, f, formValue)
 	case reflect.Struct, reflect.Slice, reflect.Map:
 		err := json.Unmarshal(string2ByteSlice(formValue), fieldValue.Addr().Interface())
 		if err != nil {
-			return ErrParseFailed{Field: fieldName, Err: err}
+			return parseError(f, fieldType, CodeParseJSON, formValue, err)
 		}
 	default:
+		return fieldError(f, ErrInvalidFieldType)
+	}
+	return nil
+}
+
+func parseError(f field, rt reflect.Type, code, formValue string, err error) error {
+	if f.opts.Contains("redact") {
+		err = redactedError{err: err, value: formValue}
+		formValue = RedactedValue
+	}
+	return ErrParseFailed{
+		Field:     f.name,
+		Key:       f.key,
+		FieldPath: f.path,
+		Value:     formValue,
+		Type:      rt,
+		Code:      code,
+		Err:       err,
+	}
+}
+
+func fieldError(f field, err error) error {
+	return ErrField{
+		Field:     f.name,
+		Key:       f.key,
+		FieldPath: f.path,
+		Type:      f.typ,
+		Err:       err,
+	}
+}
+
+// validateField reports why a field cannot be bound: an invalid tag or,
+// for value fields, a type convertValue cannot decode into.
+func (c *config) validateField(f field) error {
+	err := c.validateTag(f)
+	if err != nil {
+		return err
+	}
+	f = f.withoutOptional()
+	if isRemaining(f) || f.kind != FieldValue {
+		return nil
+	}
+	if isSplit(f) {
+		err = checkValueType(indirectType(f.typ).Elem())
+		if err != nil {
+			return fieldError(f, err)
+		}
+		return nil
+	}
+	err = checkValueType(f.typ)
+	if err != nil {
+		return fieldError(f, err)
+	}
+	return nil
+}
+
+// validateTag reports the first tag option of f that is malformed or does
+// not apply to its type, and unknown modifiers. Convert reports these for
+// every field, while a type it cannot decode only fails once it is sent.
+func (c *config) validateTag(f field) error {
+	f = f.withoutOptional()
+	if errs := tags.Check(f.opts, tagType(f)); len(errs) > 0 {
+		return fieldError(f, fmt.Errorf("%w: %w", ErrInvalidTag, errs[0]))
+	}
+	err := c.checkModifiers(f)
+	if err != nil {
+		return err
+	}
+	if isNumberType(f.typ) {
+		// the options of WithNumberFormat apply as well
+		integer := !isFloatKind(numberElem(f.typ).Kind())
+		nf := c.numberFormat(f)
+		if nf.Currency && nf.Prefixes && integer {
+			return fieldError(f, fmt.Errorf("%w: tag options %q and %q cannot be combined", ErrInvalidTag, "currency", "prefix"))
+		}
+		err = nf.checkSeparators()
+		if err != nil {
+			return fieldError(f, fmt.Errorf("%w: %w", ErrInvalidTag, err))
+		}
+	}
+	if slices.Contains(f.aliases, "") {
+		return fieldError(f, fmt.Errorf("%w: empty alias", ErrInvalidTag))
+	}
+	return nil
+}
+
+// tagType describes the type of f to tags.Check.
+func tagType(f field) tags.Type {
+	number := isNumberType(f.typ)
+	kind := numberElem(f.typ).Kind()
+	return tags.Type{
+		Time:      indirectType(f.typ) == timeType,
+		Bool:      isBoolType(f.typ),
+		Duration:  isDurationType(f.typ),
+		List:      f.kind == FieldValue && isListType(f.typ),
+		Integer:   number && isIntegerKind(kind),
+		Float:     number && isFloatKind(kind),
+		Remaining: remainingElem(f.typ) != nil,
+	}
+}
+
+// checkValueType reports ErrInvalidFieldType if convertValue cannot
+// decode into a value of the given type.
+func checkValueType(rt reflect.Type) error {
+	if decoderKind(rt) == DecoderNone {
 		return ErrInvalidFieldType
 	}
 	return nil
 }
 
+// decoderKind returns the decoder convertValue uses for rt.
+func decoderKind(rt reflect.Type) DecoderKind {
+	if elem := optionalElem(rt); elem != nil {
+		return decoderKind(elem)
+	}
+	if isDurationType(rt) && indirectType(rt).Kind() != reflect.Array {
+		return DecoderDuration
+	}
+	if reflect.PointerTo(rt).Implements(reflect.TypeFor[encoding.TextUnmarshaler]()) {
+		return DecoderText
+	}
+
+	switch rt.Kind() {
+	case reflect.Pointer:
+		return decoderKind(rt.Elem())
+	case reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64,
+		reflect.Bool,
+		reflect.Complex64, reflect.Complex128:
+		return DecoderPrimitive
+	case reflect.Array:
+		if decoderKind(rt.Elem()) == DecoderNone {
+			return DecoderNone
+		}
+		return DecoderArray
+	case reflect.Struct, reflect.Slice, reflect.Map:
+		return DecoderJSON
+	default:
+		return DecoderNone
+	}
+}
+
 func validate(rv reflect.Value) error {
 	if rv.Kind() != reflect.Ptr {
 		return ErrValueMustBePointer
@@ -171,15 +525,23 @@ func validate(rv reflect.Value) error {
 	return nil
 }
 
-func determineFieldType(rt reflect.Type) fieldType {
+func determineFieldType(rt reflect.Type) FieldKind {
 	if rt.Kind() == reflect.Pointer && rt.Elem() == reflect.TypeFor[multipart.FileHeader]() ||
 		rt == reflect.TypeFor[multipart.FileHeader]() {
-		return file
+		return FieldFile
 	} else if rt.Kind() == reflect.Slice && rt.Elem().Kind() == reflect.Pointer && rt.Elem().Elem() == reflect.TypeFor[multipart.FileHeader]() || // []*multipart.File
 		rt.Kind() == reflect.Slice && rt.Elem() == reflect.TypeFor[multipart.FileHeader]() {
-		return files
+		return FieldFiles
+	}
+	return FieldValue
+}
+
+// indirectType returns the type rt points to, following all pointers.
+func indirectType(rt reflect.Type) reflect.Type {
+	for rt.Kind() == reflect.Pointer {
+		rt = rt.Elem()
 	}
-	return value
+	return rt
 }
 
 func string2ByteSlice(s string) []byte {
@@ -4,9 +4,15 @@ import (
 	"encoding"
 	"errors"
 	"mime/multipart"
+	"net/url"
 	"reflect"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/ksckaan1/m2s/internal/tags"
 )
 
 // required for testing, not for normal usage
@@ -25,11 +31,12 @@ func TestConvert(t *testing.T) {
 		Age  int
 	}
 
-	testTime := time.Now().Round(1 * time.Second)
+	testTime := time.Now().UTC().Round(1 * time.Second)
 
 	tests := []struct {
 		name             string
 		fillMulipartForm func(*multipart.Form) error
+		opts             []Option
 		v                any
 		wantValue        any
 		checkError       func(*testing.T, error)
@@ -436,6 +443,43 @@ func TestConvert(t *testing.T) {
 				}
 			},
 		},
+		{
+			name: "invalid field type not sent",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["name"] = []string{"John"}
+				return nil
+			},
+			v: &struct {
+				Name    string `form:"name"`
+				Invalid func() `form:"invalid"`
+			}{},
+			wantValue: &struct {
+				Name    string `form:"name"`
+				Invalid func() `form:"invalid"`
+			}{Name: "John"},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "error when invalid tag not sent",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				return nil
+			},
+			v: &struct {
+				Name string `form:"name,unix"`
+			}{},
+			wantValue: &struct {
+				Name string `form:"name,unix"`
+			}{},
+			checkError: func(t *testing.T, err error) {
+				if !errors.Is(err, ErrInvalidTag) {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
 		{
 			name: "error when invalid json value",
 			fillMulipartForm: func(mpf *multipart.Form) error {
@@ -469,7 +513,7 @@ func TestConvert(t *testing.T) {
 			}{},
 			checkError: func(t *testing.T, err error) {
 				var terr ErrParseFailed
-				if !errors.As(err, &terr) || terr.Field != "Value" {
+				if !errors.As(err, &terr) || terr.Field != "Value" || terr.Key != "value" || terr.Code != CodeParseInt {
 					t.Fatal("unexpected error:", err)
 				}
 			},
@@ -532,84 +576,1736 @@ func TestConvert(t *testing.T) {
 			},
 		},
 		{
-			name: "error when parsing complex value",
+			name: "checkbox words",
 			fillMulipartForm: func(mpf *multipart.Form) error {
-				mpf.Value["value"] = []string{"invalid"}
+				mpf.Value["on"] = []string{"on"}
+				mpf.Value["yes"] = []string{"Yes"}
+				mpf.Value["checked"] = []string{"checked"}
+				mpf.Value["off"] = []string{"off"}
 				return nil
 			},
 			v: &struct {
-				Value complex64 `form:"value"`
+				On      bool  `form:"on"`
+				Yes     *bool `form:"yes"`
+				Checked bool  `form:"checked"`
+				Off     bool  `form:"off"`
+			}{Off: true},
+			wantValue: &struct {
+				On      bool  `form:"on"`
+				Yes     *bool `form:"yes"`
+				Checked bool  `form:"checked"`
+				Off     bool  `form:"off"`
+			}{On: true, Yes: ptr(true), Checked: true},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "custom bool words",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["agree"] = []string{"Evet"}
+				mpf.Value["notify"] = []string{"hayır"}
+				return nil
+			},
+			opts: []Option{WithBoolWords([]string{"evet"}, []string{"hayır"})},
+			v: &struct {
+				Agree  bool `form:"agree"`
+				Notify bool `form:"notify"`
+			}{Notify: true},
+			wantValue: &struct {
+				Agree  bool `form:"agree"`
+				Notify bool `form:"notify"`
+			}{Agree: true},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "absent checkbox is false",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				return nil
+			},
+			v: &struct {
+				Subscribe bool  `form:"subscribe,checkbox"`
+				Terms     *bool `form:"terms,checkbox"`
+				Remember  bool  `form:"remember"`
+			}{Subscribe: true, Terms: ptr(true), Remember: true},
+			wantValue: &struct {
+				Subscribe bool  `form:"subscribe,checkbox"`
+				Terms     *bool `form:"terms,checkbox"`
+				Remember  bool  `form:"remember"`
+			}{Terms: ptr(false), Remember: true},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "error when bool word is replaced",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["value"] = []string{"on"}
+				return nil
+			},
+			opts: []Option{WithBoolWords(nil, nil)},
+			v: &struct {
+				Value bool `form:"value"`
 			}{},
 			wantValue: &struct {
-				Value complex64 `form:"value"`
+				Value bool `form:"value"`
 			}{},
 			checkError: func(t *testing.T, err error) {
 				var terr ErrParseFailed
-				if !errors.As(err, &terr) || terr.Field != "Value" {
+				if !errors.As(err, &terr) || terr.Code != CodeParseBool || !errors.Is(err, strconv.ErrSyntax) {
 					t.Fatal("unexpected error:", err)
 				}
 			},
 		},
 		{
-			name: "error when parsing pointer value",
+			name: "empty values as absent",
 			fillMulipartForm: func(mpf *multipart.Form) error {
-				mpf.Value["value"] = []string{"invalid"}
+				mpf.Value["name"] = []string{""}
+				mpf.Value["age"] = []string{""}
+				mpf.Value["score"] = []string{""}
 				return nil
 			},
+			opts: []Option{WithEmptyPolicy(EmptyAsAbsent)},
 			v: &struct {
-				Value *complex64 `form:"value"`
+				Name  string         `form:"name"`
+				Age   *int           `form:"age"`
+				Score float64        `form:"score"`
+				Tags  []string       `form:"tags"`
+				Meta  map[string]int `form:"meta"`
+			}{Name: "John", Age: ptr(42), Score: 9.5},
+			wantValue: &struct {
+				Name  string         `form:"name"`
+				Age   *int           `form:"age"`
+				Score float64        `form:"score"`
+				Tags  []string       `form:"tags"`
+				Meta  map[string]int `form:"meta"`
+			}{Name: "John", Age: ptr(42), Score: 9.5},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "empty values as zero",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["name"] = []string{""}
+				mpf.Value["age"] = []string{""}
+				mpf.Value["score"] = []string{""}
+				return nil
+			},
+			opts: []Option{WithEmptyPolicy(EmptyAsZero)},
+			v: &struct {
+				Name  string         `form:"name"`
+				Age   *int           `form:"age"`
+				Score float64        `form:"score"`
+				Tags  []string       `form:"tags"`
+				Meta  map[string]int `form:"meta"`
+			}{Name: "John", Age: ptr(42), Score: 9.5},
+			wantValue: &struct {
+				Name  string         `form:"name"`
+				Age   *int           `form:"age"`
+				Score float64        `form:"score"`
+				Tags  []string       `form:"tags"`
+				Meta  map[string]int `form:"meta"`
+			}{},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "error when empty value",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["name"] = []string{""}
+				return nil
+			},
+			opts: []Option{WithEmptyPolicy(EmptyAsError)},
+			v: &struct {
+				Name  string         `form:"name"`
+				Age   *int           `form:"age"`
+				Score float64        `form:"score"`
+				Tags  []string       `form:"tags"`
+				Meta  map[string]int `form:"meta"`
 			}{},
 			wantValue: &struct {
-				Value *complex64 `form:"value"`
+				Name  string         `form:"name"`
+				Age   *int           `form:"age"`
+				Score float64        `form:"score"`
+				Tags  []string       `form:"tags"`
+				Meta  map[string]int `form:"meta"`
 			}{},
 			checkError: func(t *testing.T, err error) {
 				var terr ErrParseFailed
-				if !errors.As(err, &terr) || terr.Field != "Value" {
+				if !errors.As(err, &terr) || terr.Code != CodeEmptyValue || terr.Key != "name" || !errors.Is(err, ErrEmptyValue) {
 					t.Fatal("unexpected error:", err)
 				}
 			},
 		},
 		{
-			name: "error when parsing custom type implements encoding.TextUnmarshaler",
+			name: "null as nil",
 			fillMulipartForm: func(mpf *multipart.Form) error {
-				mpf.Value["value"] = []string{"invalid"}
+				mpf.Value["name"] = []string{"null"}
+				mpf.Value["age"] = []string{"null"}
+				mpf.Value["tags"] = []string{"null"}
+				mpf.Value["meta"] = []string{"null"}
 				return nil
 			},
+			opts: []Option{WithNullAsNil()},
 			v: &struct {
-				Value failedCustomText `form:"value"`
+				Name  string         `form:"name"`
+				Age   *int           `form:"age"`
+				Score float64        `form:"score"`
+				Tags  []string       `form:"tags"`
+				Meta  map[string]int `form:"meta"`
+			}{Age: ptr(42), Tags: []string{"a"}, Meta: map[string]int{"a": 1}},
+			wantValue: &struct {
+				Name  string         `form:"name"`
+				Age   *int           `form:"age"`
+				Score float64        `form:"score"`
+				Tags  []string       `form:"tags"`
+				Meta  map[string]int `form:"meta"`
+			}{Name: "null"},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "multi-value policies",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["first"] = []string{"", "a"}
+				mpf.Value["last"] = []string{"1", "2"}
+				mpf.Value["joined"] = []string{"a", "b"}
+				mpf.Value["any"] = []string{"", "a", "b"}
+				return nil
+			},
+			opts: []Option{WithJoinSeparator(" ")},
+			v: &struct {
+				First  string `form:"first,multi=first"`
+				Last   int    `form:"last,multi=last"`
+				Joined string `form:"joined,multi=join"`
+				Any    string `form:"any"`
 			}{},
 			wantValue: &struct {
-				Value failedCustomText `form:"value"`
+				First  string `form:"first,multi=first"`
+				Last   int    `form:"last,multi=last"`
+				Joined string `form:"joined,multi=join"`
+				Any    string `form:"any"`
+			}{Last: 2, Joined: "a b", Any: "a"},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "error when key is duplicated",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["id"] = []string{"1", "2"}
+				return nil
+			},
+			opts: []Option{WithMultiValuePolicy(MultiError)},
+			v: &struct {
+				ID int `form:"id"`
+			}{},
+			wantValue: &struct {
+				ID int `form:"id"`
 			}{},
 			checkError: func(t *testing.T, err error) {
 				var terr ErrParseFailed
-				if !errors.As(err, &terr) || terr.Field != "Value" {
+				if !errors.As(err, &terr) || terr.Code != CodeDuplicateValue || terr.Value != "1,2" || !errors.Is(err, ErrDuplicateValue) {
 					t.Fatal("unexpected error:", err)
 				}
 			},
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			mpf := &multipart.Form{
-				Value: make(map[string][]string),
-				File:  make(map[string][]*multipart.FileHeader),
-			}
-			err := tt.fillMulipartForm(mpf)
-			if err != nil {
-				t.Fatal(err)
-			}
-			err = Convert(mpf, tt.v)
-			tt.checkError(t, err)
-			if !reflect.DeepEqual(tt.v, tt.wantValue) {
-				t.Errorf("Convert() got = %v, want %v", tt.v, tt.wantValue)
-				t.Fail()
-			}
-		})
-	}
-}
-
-func ptr[T any](v T) *T {
-	return &v
+		{
+			name: "split values",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["tags"] = []string{"go,web,api"}
+				mpf.Value["ids"] = []string{" 1 2\t3 "}
+				mpf.Value["names"] = []string{" John | Jane "}
+				mpf.Value["delays"] = []string{"5,1m"}
+				mpf.Value["nothing"] = []string{""}
+				return nil
+			},
+			v: &struct {
+				Tags    []string        `form:"tags,split=,"`
+				IDs     []int           `form:"ids,split=space"`
+				Names   *[]string       `form:"names,split=|,trim"`
+				Delays  []time.Duration `form:"delays,split=,,unit=s"`
+				Nothing []string        `form:"nothing,split=,"`
+			}{},
+			wantValue: &struct {
+				Tags    []string        `form:"tags,split=,"`
+				IDs     []int           `form:"ids,split=space"`
+				Names   *[]string       `form:"names,split=|,trim"`
+				Delays  []time.Duration `form:"delays,split=,,unit=s"`
+				Nothing []string        `form:"nothing,split=,"`
+			}{
+				Tags:    []string{"go", "web", "api"},
+				IDs:     []int{1, 2, 3},
+				Names:   &[]string{"John", "Jane"},
+				Delays:  []time.Duration{5 * time.Second, time.Minute},
+				Nothing: []string{},
+			},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "error when parsing split value",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["ids"] = []string{"1,x,3"}
+				return nil
+			},
+			v: &struct {
+				IDs []int `form:"ids,split=,"`
+			}{},
+			wantValue: &struct {
+				IDs []int `form:"ids,split=,"`
+			}{},
+			checkError: func(t *testing.T, err error) {
+				var terr ErrParseFailed
+				if !errors.As(err, &terr) || terr.Code != CodeParseInt || terr.Value != "x" || terr.FieldPath != "IDs[1]" {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "arrays",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["rgb"] = []string{"0.5", "1", "0"}
+				mpf.Value["range[1]"] = []string{"z"}
+				mpf.Value["range[0]"] = []string{"a"}
+				mpf.Value["point"] = []string{"[3,4]"}
+				mpf.Value["size"] = []string{"800x600"}
+				mpf.Value["single"] = []string{"only"}
+				return nil
+			},
+			v: &struct {
+				RGB     [3]float64 `form:"rgb"`
+				Range   *[2]string `form:"range"`
+				Point   [2]int     `form:"point"`
+				Size    [2]int     `form:"size,split=x"`
+				Single  [1]string  `form:"single"`
+				Missing [2]int     `form:"missing"`
+			}{},
+			wantValue: &struct {
+				RGB     [3]float64 `form:"rgb"`
+				Range   *[2]string `form:"range"`
+				Point   [2]int     `form:"point"`
+				Size    [2]int     `form:"size,split=x"`
+				Single  [1]string  `form:"single"`
+				Missing [2]int     `form:"missing"`
+			}{
+				RGB:    [3]float64{0.5, 1, 0},
+				Range:  &[2]string{"a", "z"},
+				Point:  [2]int{3, 4},
+				Size:   [2]int{800, 600},
+				Single: [1]string{"only"},
+			},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "JSON arrays convert each element",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["timeouts"] = []string{`["90s","1m"]`}
+				mpf.Value["delays"] = []string{"[1.5,null]"}
+				mpf.Value["prices"] = []string{`["1,5",2]`}
+				return nil
+			},
+			v: &struct {
+				Timeouts [2]time.Duration  `form:"timeouts"`
+				Delays   [2]*time.Duration `form:"delays,unit=s"`
+				Prices   [2]float64        `form:"prices,decimal=,"`
+			}{},
+			wantValue: &struct {
+				Timeouts [2]time.Duration  `form:"timeouts"`
+				Delays   [2]*time.Duration `form:"delays,unit=s"`
+				Prices   [2]float64        `form:"prices,decimal=,"`
+			}{
+				Timeouts: [2]time.Duration{90 * time.Second, time.Minute},
+				Delays:   [2]*time.Duration{ptr(1500 * time.Millisecond), nil},
+				Prices:   [2]float64{1.5, 2},
+			},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "error when JSON array of durations has no unit",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["timeouts"] = []string{"[90,60]"}
+				return nil
+			},
+			v: &struct {
+				Timeouts [2]time.Duration `form:"timeouts"`
+			}{},
+			wantValue: &struct {
+				Timeouts [2]time.Duration `form:"timeouts"`
+			}{},
+			checkError: func(t *testing.T, err error) {
+				var terr ErrParseFailed
+				if !errors.As(err, &terr) || terr.Code != CodeParseDuration || terr.FieldPath != "Timeouts[0]" {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "error when too few repeated keys for array",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["rgb"] = []string{"1", "2"}
+				return nil
+			},
+			v: &struct {
+				RGB [3]float64 `form:"rgb"`
+			}{},
+			wantValue: &struct {
+				RGB [3]float64 `form:"rgb"`
+			}{},
+			checkError: func(t *testing.T, err error) {
+				var terr ErrParseFailed
+				if !errors.As(err, &terr) || terr.Code != CodeLengthMismatch || !errors.Is(err, ErrLengthMismatch) {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "error when JSON array is too long",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["rgb"] = []string{"[1,2,3,4]"}
+				return nil
+			},
+			v: &struct {
+				RGB [3]float64 `form:"rgb"`
+			}{},
+			wantValue: &struct {
+				RGB [3]float64 `form:"rgb"`
+			}{},
+			checkError: func(t *testing.T, err error) {
+				var terr ErrParseFailed
+				if !errors.As(err, &terr) || terr.Code != CodeLengthMismatch || !errors.Is(err, ErrLengthMismatch) {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "error when parsing array element",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["rgb"] = []string{"1", "2", "blue"}
+				return nil
+			},
+			v: &struct {
+				RGB [3]float64 `form:"rgb"`
+			}{},
+			wantValue: &struct {
+				RGB [3]float64 `form:"rgb"`
+			}{},
+			checkError: func(t *testing.T, err error) {
+				var terr ErrParseFailed
+				if !errors.As(err, &terr) || terr.Code != CodeParseFloat || terr.FieldPath != "RGB[2]" || terr.Field != "RGB" {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "error when indexed key is missing",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["rgb[0]"] = []string{"1"}
+				mpf.Value["rgb[2]"] = []string{"3"}
+				return nil
+			},
+			v: &struct {
+				RGB [3]float64 `form:"rgb"`
+			}{},
+			wantValue: &struct {
+				RGB [3]float64 `form:"rgb"`
+			}{},
+			checkError: func(t *testing.T, err error) {
+				var terr ErrParseFailed
+				if !errors.As(err, &terr) || terr.Code != CodeLengthMismatch || !errors.Is(err, ErrLengthMismatch) {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "error when index is not canonical",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["rgb[0]"] = []string{"1"}
+				mpf.Value["rgb[+1]"] = []string{"2"}
+				mpf.Value["rgb[2]"] = []string{"3"}
+				return nil
+			},
+			v: &struct {
+				RGB [3]float64 `form:"rgb"`
+			}{},
+			wantValue: &struct {
+				RGB [3]float64 `form:"rgb"`
+			}{},
+			checkError: func(t *testing.T, err error) {
+				var terr ErrParseFailed
+				if !errors.As(err, &terr) || terr.Code != CodeLengthMismatch || !errors.Is(err, ErrLengthMismatch) {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "error when index is sent twice with leading zeros",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["rgb[1]"] = []string{"1"}
+				mpf.Value["rgb[001]"] = []string{"2"}
+				mpf.Value["rgb[0]"] = []string{"3"}
+				return nil
+			},
+			v: &struct {
+				RGB [3]float64 `form:"rgb"`
+			}{},
+			wantValue: &struct {
+				RGB [3]float64 `form:"rgb"`
+			}{},
+			checkError: func(t *testing.T, err error) {
+				if !errors.Is(err, ErrLengthMismatch) {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "error when index is out of range",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["rgb[3]"] = []string{"1"}
+				return nil
+			},
+			v: &struct {
+				RGB [3]float64 `form:"rgb"`
+			}{},
+			wantValue: &struct {
+				RGB [3]float64 `form:"rgb"`
+			}{},
+			checkError: func(t *testing.T, err error) {
+				var terr ErrParseFailed
+				if !errors.As(err, &terr) || terr.Code != CodeLengthMismatch || !errors.Is(err, ErrLengthMismatch) {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "error when split array is too short",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["rgb"] = []string{"1,2"}
+				return nil
+			},
+			v: &struct {
+				RGB [3]float64 `form:"rgb,split=,"`
+			}{},
+			wantValue: &struct {
+				RGB [3]float64 `form:"rgb,split=,"`
+			}{},
+			checkError: func(t *testing.T, err error) {
+				var terr ErrParseFailed
+				if !errors.As(err, &terr) || terr.Code != CodeLengthMismatch || !errors.Is(err, ErrLengthMismatch) {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "strict mode with known keys",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["name"] = []string{"John"}
+				mpf.Value["rgb[0]"] = []string{"1"}
+				mpf.Value["rgb[1]"] = []string{"2"}
+				mpf.Value["rgb[2]"] = []string{"3"}
+				mpf.File["photo"] = []*multipart.FileHeader{{Filename: "photo.png"}}
+				return nil
+			},
+			opts: []Option{WithStrict()},
+			v: &struct {
+				Name  string                `form:"name"`
+				RGB   [3]int                `form:"rgb"`
+				Photo *multipart.FileHeader `form:"photo"`
+			}{},
+			wantValue: &struct {
+				Name  string                `form:"name"`
+				RGB   [3]int                `form:"rgb"`
+				Photo *multipart.FileHeader `form:"photo"`
+			}{Name: "John", RGB: [3]int{1, 2, 3}, Photo: &multipart.FileHeader{Filename: "photo.png"}},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "error when strict mode finds unknown keys",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["name"] = []string{"John"}
+				mpf.Value["nmae"] = []string{"John"}
+				mpf.Value["photo"] = []string{"photo.png"}
+				mpf.File["name"] = []*multipart.FileHeader{{Filename: "name.txt"}}
+				return nil
+			},
+			opts: []Option{WithStrict()},
+			v: &struct {
+				Name  string                `form:"name"`
+				RGB   [3]int                `form:"rgb"`
+				Photo *multipart.FileHeader `form:"photo"`
+			}{},
+			wantValue: &struct {
+				Name  string                `form:"name"`
+				RGB   [3]int                `form:"rgb"`
+				Photo *multipart.FileHeader `form:"photo"`
+			}{Name: "John"},
+			checkError: func(t *testing.T, err error) {
+				var uerr ErrUnknownFields
+				if !errors.As(err, &uerr) || ErrorCode(err) != CodeUnknownFields ||
+					!reflect.DeepEqual(uerr.Values, []string{"nmae", "photo"}) || !reflect.DeepEqual(uerr.Files, []string{"name"}) {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "remaining values and files",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["name"] = []string{"John"}
+				mpf.Value["plugin.color"] = []string{"red", "blue"}
+				mpf.File["photo"] = []*multipart.FileHeader{{Filename: "photo.png"}}
+				mpf.File["plugin.logo"] = []*multipart.FileHeader{{Filename: "logo.png"}}
+				return nil
+			},
+			opts: []Option{WithStrict()},
+			v: &struct {
+				Name  string                             `form:"name"`
+				Photo *multipart.FileHeader              `form:"photo"`
+				Extra map[string][]string                `form:",remaining"`
+				Files map[string][]*multipart.FileHeader `form:",remaining"`
+			}{},
+			wantValue: &struct {
+				Name  string                             `form:"name"`
+				Photo *multipart.FileHeader              `form:"photo"`
+				Extra map[string][]string                `form:",remaining"`
+				Files map[string][]*multipart.FileHeader `form:",remaining"`
+			}{
+				Name:  "John",
+				Photo: &multipart.FileHeader{Filename: "photo.png"},
+				Extra: map[string][]string{"plugin.color": {"red", "blue"}},
+				Files: map[string][]*multipart.FileHeader{"plugin.logo": {{Filename: "logo.png"}}},
+			},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "error when strict mode finds unknown files next to remaining values",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["plugin.color"] = []string{"red"}
+				mpf.File["plugin.logo"] = []*multipart.FileHeader{{Filename: "logo.png"}}
+				return nil
+			},
+			opts: []Option{WithStrict()},
+			v: &struct {
+				Extra url.Values `form:",remaining"`
+			}{},
+			wantValue: &struct {
+				Extra url.Values `form:",remaining"`
+			}{Extra: url.Values{"plugin.color": {"red"}}},
+			checkError: func(t *testing.T, err error) {
+				var uerr ErrUnknownFields
+				if !errors.As(err, &uerr) || len(uerr.Values) != 0 || !reflect.DeepEqual(uerr.Files, []string{"plugin.logo"}) {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "key naming",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["first_name"] = []string{"John"}
+				mpf.Value["user_id"] = []string{"42"}
+				mpf.Value["nickname"] = []string{"johnny"}
+				return nil
+			},
+			opts: []Option{WithKeyNaming(SnakeCase), WithStrict()},
+			v: &struct {
+				FirstName string `form:""`
+				UserID    int
+				Nick      string `form:"nickname"`
+			}{},
+			wantValue: &struct {
+				FirstName string `form:""`
+				UserID    int
+				Nick      string `form:"nickname"`
+			}{FirstName: "John", UserID: 42, Nick: "johnny"},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "case-insensitive keys",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["FIRSTNAME"] = []string{"John"}
+				mpf.Value["RGB[0]"] = []string{"1"}
+				mpf.Value["Rgb[1]"] = []string{"2"}
+				mpf.File["photo"] = []*multipart.FileHeader{{Filename: "photo.png"}}
+				return nil
+			},
+			opts: []Option{WithCaseInsensitiveKeys(), WithStrict()},
+			v: &struct {
+				FirstName string `form:"firstName"`
+				RGB       [2]int `form:"rgb"`
+				Photo     *multipart.FileHeader
+			}{},
+			wantValue: &struct {
+				FirstName string `form:"firstName"`
+				RGB       [2]int `form:"rgb"`
+				Photo     *multipart.FileHeader
+			}{FirstName: "John", RGB: [2]int{1, 2}, Photo: &multipart.FileHeader{Filename: "photo.png"}},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "exact key wins over case-insensitive match",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["Name"] = []string{"Jane"}
+				mpf.Value["name"] = []string{"John"}
+				return nil
+			},
+			opts: []Option{WithCaseInsensitiveKeys()},
+			v: &struct {
+				Name string `form:"name"`
+			}{},
+			wantValue: &struct {
+				Name string `form:"name"`
+			}{Name: "John"},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "json tag fallback",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["full_name"] = []string{"John"}
+				mpf.Value["email"] = []string{"john@example.com"}
+				mpf.Value["Secret"] = []string{"s3cr3t"}
+				mpf.Value["-"] = []string{"dash"}
+				mpf.Value["age"] = []string{"42"}
+				mpf.Value["Untaged"] = []string{"untaged"}
+				return nil
+			},
+			opts: []Option{WithJSONFallback()},
+			v: &struct {
+				Name    string `json:"full_name"`
+				Email   string `form:"email" json:"mail"`
+				Secret  string `json:"-"`
+				Dash    string `json:"-,"`
+				Age     int    `json:"age,omitempty,string"`
+				Untaged string
+			}{},
+			wantValue: &struct {
+				Name    string `json:"full_name"`
+				Email   string `form:"email" json:"mail"`
+				Secret  string `json:"-"`
+				Dash    string `json:"-,"`
+				Age     int    `json:"age,omitempty,string"`
+				Untaged string
+			}{Name: "John", Email: "john@example.com", Dash: "dash", Age: 42, Untaged: "untaged"},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "custom tag name",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["name"] = []string{"John"}
+				mpf.Value["form_name"] = []string{"Jane"}
+				return nil
+			},
+			opts: []Option{WithTagName("m2s")},
+			v: &struct {
+				Name string `m2s:"name" form:"form_name"`
+				Skip string `m2s:"-" form:"form_name"`
+			}{},
+			wantValue: &struct {
+				Name string `m2s:"name" form:"form_name"`
+				Skip string `m2s:"-" form:"form_name"`
+			}{Name: "John"},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "aliases",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["e_mail"] = []string{"old@example.com"}
+				mpf.Value["mail"] = []string{"john@example.com"}
+				mpf.Value["phone"] = []string{"555"}
+				mpf.Value["tel"] = []string{"666"}
+				mpf.Value["color[0]"] = []string{"1"}
+				mpf.Value["color[1]"] = []string{"2"}
+				mpf.File["avatar"] = []*multipart.FileHeader{{Filename: "avatar.png"}}
+				return nil
+			},
+			opts: []Option{WithStrict()},
+			v: &struct {
+				Email string                `form:"email|mail,alias=e_mail"`
+				Phone string                `form:"phone|tel"`
+				RGB   [2]int                `form:"rgb,alias=color"`
+				Photo *multipart.FileHeader `form:"photo|avatar"`
+			}{},
+			wantValue: &struct {
+				Email string                `form:"email|mail,alias=e_mail"`
+				Phone string                `form:"phone|tel"`
+				RGB   [2]int                `form:"rgb,alias=color"`
+				Photo *multipart.FileHeader `form:"photo|avatar"`
+			}{
+				Email: "john@example.com",
+				Phone: "555",
+				RGB:   [2]int{1, 2},
+				Photo: &multipart.FileHeader{Filename: "avatar.png"},
+			},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "number formats",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["price"] = []string{"1.234,56"}
+				mpf.Value["total"] = []string{"€ 1.000,5"}
+				mpf.Value["rate"] = []string{"12.5%"}
+				mpf.Value["discount"] = []string{"7,5"}
+				mpf.Value["count"] = []string{"1\u202f234 567"}
+				mpf.Value["mask"] = []string{"0xFF_FF"}
+				mpf.Value["offset"] = []string{"-0b101"}
+				mpf.Value["big"] = []string{"1_000_000"}
+				mpf.Value["weights"] = []string{"0,5;1.000,25"}
+				return nil
+			},
+			opts: []Option{WithNumberFormat(NumberFormat{Decimal: ",", Group: "."})},
+			v: &struct {
+				Price    float64   `form:"price"`
+				Total    *float32  `form:"total,currency"`
+				Rate     float64   `form:"rate,percent,decimal=.,group=,"`
+				Discount float64   `form:"discount,percent"`
+				Count    int       `form:"count,group=space"`
+				Mask     uint16    `form:"mask,prefix,underscores"`
+				Offset   int8      `form:"offset,prefix"`
+				Big      int64     `form:"big,underscores"`
+				Weights  []float64 `form:"weights,split=;"`
+			}{},
+			wantValue: &struct {
+				Price    float64   `form:"price"`
+				Total    *float32  `form:"total,currency"`
+				Rate     float64   `form:"rate,percent,decimal=.,group=,"`
+				Discount float64   `form:"discount,percent"`
+				Count    int       `form:"count,group=space"`
+				Mask     uint16    `form:"mask,prefix,underscores"`
+				Offset   int8      `form:"offset,prefix"`
+				Big      int64     `form:"big,underscores"`
+				Weights  []float64 `form:"weights,split=;"`
+			}{
+				Price:    1234.56,
+				Total:    ptr[float32](1000.5),
+				Rate:     0.125,
+				Discount: 0.075,
+				Count:    1234567,
+				Mask:     0xFFFF,
+				Offset:   -5,
+				Big:      1000000,
+				Weights:  []float64{0.5, 1000.25},
+			},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "currency codes and symbols",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["fee"] = []string{"12.50 EUR"}
+				mpf.Value["total"] = []string{"USD\u00a01000"}
+				mpf.Value["tip"] = []string{"$3.5"}
+				return nil
+			},
+			v: &struct {
+				Fee   float64 `form:"fee,currency"`
+				Total float64 `form:"total,currency"`
+				Tip   float64 `form:"tip,currency"`
+			}{},
+			wantValue: &struct {
+				Fee   float64 `form:"fee,currency"`
+				Total float64 `form:"total,currency"`
+				Tip   float64 `form:"tip,currency"`
+			}{Fee: 12.5, Total: 1000, Tip: 3.5},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "error when currency option meets other letters",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["count"] = []string{"12 apples"}
+				return nil
+			},
+			v: &struct {
+				Count int `form:"count,currency"`
+			}{},
+			wantValue: &struct {
+				Count int `form:"count,currency"`
+			}{},
+			checkError: func(t *testing.T, err error) {
+				var terr ErrParseFailed
+				if !errors.As(err, &terr) || terr.Code != CodeParseInt {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "error when currency option is combined with prefix",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["i"] = []string{"0xFF"}
+				return nil
+			},
+			v: &struct {
+				I int `form:"i,prefix,currency"`
+			}{},
+			wantValue: &struct {
+				I int `form:"i,prefix,currency"`
+			}{},
+			checkError: func(t *testing.T, err error) {
+				if !errors.Is(err, ErrInvalidTag) {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "error when number uses another decimal separator",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["price"] = []string{"12.50"}
+				return nil
+			},
+			v: &struct {
+				Price float64 `form:"price,decimal=,"`
+			}{},
+			wantValue: &struct {
+				Price float64 `form:"price,decimal=,"`
+			}{},
+			checkError: func(t *testing.T, err error) {
+				var terr ErrParseFailed
+				if !errors.As(err, &terr) || terr.Code != CodeParseFloat || terr.Key != "price" {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "modifiers",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["name"] = []string{"  John   van\tDoe "}
+				mpf.Value["email"] = []string{" John@Example.COM\n"}
+				mpf.Value["age"] = []string{" 42 "}
+				mpf.Value["bio"] = []string{"a\x00b\r\nc"}
+				mpf.Value["rgb"] = []string{" 1", "2 ", " 3 "}
+				mpf.Value["code"] = []string{"ab-12"}
+				mpf.Value["blank"] = []string{"  "}
+				mpf.Value["nick"] = []string{"  ", " Johnny "}
+				return nil
+			},
+			opts: []Option{
+				WithEmptyPolicy(EmptyAsAbsent),
+				WithModifier("nodash", func(s string) string { return strings.ReplaceAll(s, "-", "") }),
+			},
+			v: &struct {
+				Name  string `form:"name" mod:"collapse,trim"`
+				Email string `form:"email" mod:"trim,lower"`
+				Age   int    `form:"age" mod:"trim"`
+				Bio   string `form:"bio" mod:"strip_control"`
+				RGB   [3]int `form:"rgb" mod:"trim"`
+				Code  string `form:"code" mod:"nodash,upper"`
+				Blank *int   `form:"blank" mod:"trim"`
+				Nick  string `form:"nick" mod:"trim"`
+			}{},
+			wantValue: &struct {
+				Name  string `form:"name" mod:"collapse,trim"`
+				Email string `form:"email" mod:"trim,lower"`
+				Age   int    `form:"age" mod:"trim"`
+				Bio   string `form:"bio" mod:"strip_control"`
+				RGB   [3]int `form:"rgb" mod:"trim"`
+				Code  string `form:"code" mod:"nodash,upper"`
+				Blank *int   `form:"blank" mod:"trim"`
+				Nick  string `form:"nick" mod:"trim"`
+			}{
+				Name:  "John van Doe",
+				Email: "john@example.com",
+				Age:   42,
+				Bio:   "ab\r\nc",
+				RGB:   [3]int{1, 2, 3},
+				Code:  "AB12",
+				Nick:  "Johnny",
+			},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "error when modifier is unknown",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["name"] = []string{"John"}
+				return nil
+			},
+			v: &struct {
+				Name string `form:"name" mod:"trim,titl"`
+			}{},
+			wantValue: &struct {
+				Name string `form:"name" mod:"trim,titl"`
+			}{},
+			checkError: func(t *testing.T, err error) {
+				if !errors.Is(err, ErrInvalidTag) {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "zero missing fields",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["name"] = []string{"John"}
+				mpf.Value["other"] = []string{"x"}
+				return nil
+			},
+			opts: []Option{WithZeroMissing()},
+			v: &struct {
+				Name   string                  `form:"name"`
+				Age    *int                    `form:"age"`
+				Tags   []string                `form:"tags"`
+				Photos []*multipart.FileHeader `form:"photos"`
+				Extra  map[string][]string     `form:",remaining"`
+			}{
+				Name:   "Jane",
+				Age:    ptr(42),
+				Tags:   []string{"a"},
+				Photos: []*multipart.FileHeader{{Filename: "photo.png"}},
+				Extra:  map[string][]string{"stale": {"y"}},
+			},
+			wantValue: &struct {
+				Name   string                  `form:"name"`
+				Age    *int                    `form:"age"`
+				Tags   []string                `form:"tags"`
+				Photos []*multipart.FileHeader `form:"photos"`
+				Extra  map[string][]string     `form:",remaining"`
+			}{
+				Name:  "John",
+				Extra: map[string][]string{"other": {"x"}},
+			},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "append to slices",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["tags"] = []string{"c,d"}
+				mpf.Value["ids"] = []string{"[3]"}
+				mpf.Value["empty"] = []string{"[1]"}
+				mpf.Value["null"] = []string{"null"}
+				return nil
+			},
+			opts: []Option{WithSlicePolicy(SliceAppend), WithNullAsNil()},
+			v: &struct {
+				Tags  []string `form:"tags,split=,"`
+				IDs   *[]int   `form:"ids"`
+				Empty []int    `form:"empty"`
+				Null  []int    `form:"null"`
+				Kept  []int    `form:"kept"`
+			}{
+				Tags: []string{"a", "b"},
+				IDs:  &[]int{1, 2},
+				Null: []int{1},
+				Kept: []int{1},
+			},
+			wantValue: &struct {
+				Tags  []string `form:"tags,split=,"`
+				IDs   *[]int   `form:"ids"`
+				Empty []int    `form:"empty"`
+				Null  []int    `form:"null"`
+				Kept  []int    `form:"kept"`
+			}{
+				Tags:  []string{"a", "b", "c", "d"},
+				IDs:   &[]int{1, 2, 3},
+				Empty: []int{1},
+				Kept:  []int{1},
+			},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "optional values",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["name"] = []string{""}
+				mpf.Value["bio"] = []string{"null"}
+				mpf.Value["born"] = []string{"2026-10-18"}
+				mpf.Value["tags"] = []string{"a,b"}
+				return nil
+			},
+			opts: []Option{WithNullAsNil()},
+			v: &struct {
+				Name  Optional[string]    `form:"name"`
+				Age   Optional[int]       `form:"age"`
+				Bio   Optional[string]    `form:"bio"`
+				Born  Optional[time.Time] `form:"born,layout=2006-01-02"`
+				Tags  Optional[[]string]  `form:"tags,split=,"`
+				Agree Optional[bool]      `form:"agree,checkbox"`
+			}{Age: Some(42)},
+			wantValue: &struct {
+				Name  Optional[string]    `form:"name"`
+				Age   Optional[int]       `form:"age"`
+				Bio   Optional[string]    `form:"bio"`
+				Born  Optional[time.Time] `form:"born,layout=2006-01-02"`
+				Tags  Optional[[]string]  `form:"tags,split=,"`
+				Agree Optional[bool]      `form:"agree,checkbox"`
+			}{
+				Name:  Optional[string]{Set: true},
+				Age:   Some(42),
+				Bio:   Optional[string]{Set: true, Null: true},
+				Born:  Some(time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)),
+				Tags:  Some([]string{"a", "b"}),
+				Agree: Some(false),
+			},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "optional arrays from repeated and indexed keys",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["rgb"] = []string{"255", "128", "0"}
+				mpf.Value["pos[1]"] = []string{"4"}
+				mpf.Value["pos[0]"] = []string{"3"}
+				mpf.Value["sz[0]"] = []string{"800"}
+				mpf.Value["sz[1]"] = []string{"600"}
+				return nil
+			},
+			opts: []Option{WithStrict()},
+			v: &struct {
+				RGB  Optional[[3]int]  `form:"rgb"`
+				Pos  Optional[[2]int]  `form:"pos"`
+				Size Optional[*[2]int] `form:"size,alias=sz"`
+			}{},
+			wantValue: &struct {
+				RGB  Optional[[3]int]  `form:"rgb"`
+				Pos  Optional[[2]int]  `form:"pos"`
+				Size Optional[*[2]int] `form:"size,alias=sz"`
+			}{
+				RGB:  Some([3]int{255, 128, 0}),
+				Pos:  Some([2]int{3, 4}),
+				Size: Some(&[2]int{800, 600}),
+			},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "optional values without null option",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["name"] = []string{"null"}
+				mpf.Value["age"] = []string{""}
+				return nil
+			},
+			opts: []Option{WithEmptyPolicy(EmptyAsZero)},
+			v: &struct {
+				Name Optional[string] `form:"name"`
+				Age  Optional[int]    `form:"age"`
+			}{Age: Some(42)},
+			wantValue: &struct {
+				Name Optional[string] `form:"name"`
+				Age  Optional[int]    `form:"age"`
+			}{Name: Some("null"), Age: Some(0)},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "error when parsing optional value",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["age"] = []string{"old"}
+				return nil
+			},
+			v: &struct {
+				Age Optional[int] `form:"age"`
+			}{},
+			wantValue: &struct {
+				Age Optional[int] `form:"age"`
+			}{},
+			checkError: func(t *testing.T, err error) {
+				var terr ErrParseFailed
+				if !errors.As(err, &terr) || terr.Code != CodeParseInt || terr.Key != "age" {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "error when parsing complex value",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["value"] = []string{"invalid"}
+				return nil
+			},
+			v: &struct {
+				Value complex64 `form:"value"`
+			}{},
+			wantValue: &struct {
+				Value complex64 `form:"value"`
+			}{},
+			checkError: func(t *testing.T, err error) {
+				var terr ErrParseFailed
+				if !errors.As(err, &terr) || terr.Field != "Value" {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "error when parsing pointer value",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["value"] = []string{"invalid"}
+				return nil
+			},
+			v: &struct {
+				Value *complex64 `form:"value"`
+			}{},
+			wantValue: &struct {
+				Value *complex64 `form:"value"`
+			}{},
+			checkError: func(t *testing.T, err error) {
+				var terr ErrParseFailed
+				if !errors.As(err, &terr) || terr.Field != "Value" {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "error when parsing custom type implements encoding.TextUnmarshaler",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["value"] = []string{"invalid"}
+				return nil
+			},
+			v: &struct {
+				Value failedCustomText `form:"value"`
+			}{},
+			wantValue: &struct {
+				Value failedCustomText `form:"value"`
+			}{},
+			checkError: func(t *testing.T, err error) {
+				var terr ErrParseFailed
+				if !errors.As(err, &terr) || terr.Field != "Value" {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "error wraps strconv range error",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["value"] = []string{"300"}
+				return nil
+			},
+			v: &struct {
+				Value int8 `form:"value"`
+			}{},
+			wantValue: &struct {
+				Value int8 `form:"value"`
+			}{},
+			checkError: func(t *testing.T, err error) {
+				var terr ErrParseFailed
+				if !errors.As(err, &terr) || !errors.Is(err, strconv.ErrRange) {
+					t.Fatal("unexpected error:", err)
+				}
+				if terr.Key != "value" || terr.FieldPath != "Value" || terr.Value != "300" || terr.Type != reflect.TypeFor[int8]() {
+					t.Fatalf("unexpected error fields: %+v", terr)
+				}
+			},
+		},
+		{
+			name: "error wraps time parse error",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["time"] = []string{"invalid"}
+				return nil
+			},
+			v: &struct {
+				Time *time.Time `form:"time"`
+			}{},
+			wantValue: &struct {
+				Time *time.Time `form:"time"`
+			}{},
+			checkError: func(t *testing.T, err error) {
+				var perr *time.ParseError
+				if !errors.As(err, &perr) {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "error redacts value",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["pin"] = []string{"secret"}
+				return nil
+			},
+			v: &struct {
+				Pin int `form:"pin,redact"`
+			}{},
+			wantValue: &struct {
+				Pin int `form:"pin,redact"`
+			}{},
+			checkError: func(t *testing.T, err error) {
+				var terr ErrParseFailed
+				if !errors.As(err, &terr) || terr.Value != RedactedValue || terr.Key != "pin" {
+					t.Fatal("unexpected error:", err)
+				}
+				var nerr *strconv.NumError
+				if !errors.As(err, &nerr) || strings.Contains(err.Error(), "secret") {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "error wraps invalid field type with field",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["invalid"] = []string{"invalid"}
+				return nil
+			},
+			v: &struct {
+				Invalid chan int `form:"invalid"`
+			}{},
+			wantValue: &struct {
+				Invalid chan int `form:"invalid"`
+			}{},
+			checkError: func(t *testing.T, err error) {
+				var terr ErrField
+				if !errors.As(err, &terr) || !errors.Is(err, ErrInvalidFieldType) || terr.Field != "Invalid" || terr.Key != "invalid" {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "time layouts",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["date"] = []string{"2026-10-16"}
+				mpf.Value["local"] = []string{"2026-10-16T14:30"}
+				mpf.Value["clock"] = []string{"14:30"}
+				mpf.Value["fallback"] = []string{"2026-10-16"}
+				return nil
+			},
+			v: &struct {
+				Date     time.Time  `form:"date,layout=2006-01-02"`
+				Local    *time.Time `form:"local,layout=2006-01-02T15:04"`
+				Clock    time.Time  `form:"clock,layout=15:04"`
+				Fallback time.Time  `form:"fallback,layout=2006-01-02T15:04|2006-01-02"`
+			}{},
+			wantValue: &struct {
+				Date     time.Time  `form:"date,layout=2006-01-02"`
+				Local    *time.Time `form:"local,layout=2006-01-02T15:04"`
+				Clock    time.Time  `form:"clock,layout=15:04"`
+				Fallback time.Time  `form:"fallback,layout=2006-01-02T15:04|2006-01-02"`
+			}{
+				Date:     time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC),
+				Local:    ptr(time.Date(2026, 10, 16, 14, 30, 0, 0, time.UTC)),
+				Clock:    time.Date(0, 1, 1, 14, 30, 0, 0, time.UTC),
+				Fallback: time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC),
+			},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "time location and global layouts",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["local"] = []string{"2026-10-16T14:30"}
+				mpf.Value["zoned"] = []string{"2026-10-16T14:30:00Z"}
+				return nil
+			},
+			opts: []Option{
+				WithLocation(time.FixedZone("UTC+3", 3*60*60)),
+				WithTimeLayouts("2006-01-02T15:04"),
+			},
+			v: &struct {
+				Local time.Time `form:"local"`
+				Zoned time.Time `form:"zoned"`
+			}{},
+			wantValue: &struct {
+				Local time.Time `form:"local"`
+				Zoned time.Time `form:"zoned"`
+			}{
+				Local: time.Date(2026, 10, 16, 14, 30, 0, 0, time.FixedZone("UTC+3", 3*60*60)),
+				Zoned: time.Date(2026, 10, 16, 14, 30, 0, 0, time.UTC),
+			},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "unix timestamps",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["sec"] = []string{"1760625000"}
+				mpf.Value["msec"] = []string{"1760625000123"}
+				return nil
+			},
+			v: &struct {
+				Sec  time.Time  `form:"sec,unix"`
+				Msec *time.Time `form:"msec,unixmilli"`
+			}{},
+			wantValue: &struct {
+				Sec  time.Time  `form:"sec,unix"`
+				Msec *time.Time `form:"msec,unixmilli"`
+			}{
+				Sec:  time.Unix(1760625000, 0).UTC(),
+				Msec: ptr(time.UnixMilli(1760625000123).UTC()),
+			},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "error when no time layout matches",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["date"] = []string{"16.10.2026"}
+				return nil
+			},
+			v: &struct {
+				Date time.Time `form:"date,layout=2006-01-02|15:04"`
+			}{},
+			wantValue: &struct {
+				Date time.Time `form:"date,layout=2006-01-02|15:04"`
+			}{},
+			checkError: func(t *testing.T, err error) {
+				var terr ErrParseFailed
+				var perr *time.ParseError
+				if !errors.As(err, &terr) || terr.Code != CodeParseTime || !errors.As(err, &perr) || perr.Layout != "2006-01-02" {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "error when parsing unix timestamp",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["sec"] = []string{"soon"}
+				return nil
+			},
+			v: &struct {
+				Sec time.Time `form:"sec,unix"`
+			}{},
+			wantValue: &struct {
+				Sec time.Time `form:"sec,unix"`
+			}{},
+			checkError: func(t *testing.T, err error) {
+				var terr ErrParseFailed
+				if !errors.As(err, &terr) || terr.Code != CodeParseTime || !errors.Is(err, strconv.ErrSyntax) {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "durations",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["timeout"] = []string{"1h30m"}
+				mpf.Value["retention"] = []string{"90"}
+				mpf.Value["grace"] = []string{"1.5"}
+				mpf.Value["intervals"] = []string{`["90s","2m"]`}
+				mpf.Value["delays"] = []string{`[250,"1s",null]`}
+				return nil
+			},
+			v: &struct {
+				Timeout   time.Duration    `form:"timeout"`
+				Retention *time.Duration   `form:"retention,unit=s"`
+				Grace     time.Duration    `form:"grace,unit=m"`
+				Intervals []time.Duration  `form:"intervals"`
+				Delays    []*time.Duration `form:"delays,unit=ms"`
+			}{},
+			wantValue: &struct {
+				Timeout   time.Duration    `form:"timeout"`
+				Retention *time.Duration   `form:"retention,unit=s"`
+				Grace     time.Duration    `form:"grace,unit=m"`
+				Intervals []time.Duration  `form:"intervals"`
+				Delays    []*time.Duration `form:"delays,unit=ms"`
+			}{
+				Timeout:   90 * time.Minute,
+				Retention: ptr(90 * time.Second),
+				Grace:     90 * time.Second,
+				Intervals: []time.Duration{90 * time.Second, 2 * time.Minute},
+				Delays:    []*time.Duration{ptr(250 * time.Millisecond), ptr(time.Second), nil},
+			},
+			checkError: func(t *testing.T, err error) {
+				if err != nil {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "error when duration has no unit",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["timeout"] = []string{"90"}
+				return nil
+			},
+			v: &struct {
+				Timeout time.Duration `form:"timeout"`
+			}{},
+			wantValue: &struct {
+				Timeout time.Duration `form:"timeout"`
+			}{},
+			checkError: func(t *testing.T, err error) {
+				var terr ErrParseFailed
+				if !errors.As(err, &terr) || terr.Code != CodeParseDuration || terr.Value != "90" {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+		{
+			name: "error when parsing duration in slice",
+			fillMulipartForm: func(mpf *multipart.Form) error {
+				mpf.Value["intervals"] = []string{`["90s","soon"]`}
+				return nil
+			},
+			v: &struct {
+				Intervals []time.Duration `form:"intervals"`
+			}{},
+			wantValue: &struct {
+				Intervals []time.Duration `form:"intervals"`
+			}{},
+			checkError: func(t *testing.T, err error) {
+				var terr ErrParseFailed
+				if !errors.As(err, &terr) || terr.Code != CodeParseDuration || terr.Value != "soon" || terr.FieldPath != "Intervals[1]" {
+					t.Fatal("unexpected error:", err)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mpf := &multipart.Form{
+				Value: make(map[string][]string),
+				File:  make(map[string][]*multipart.FileHeader),
+			}
+			err := tt.fillMulipartForm(mpf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = Convert(mpf, tt.v, tt.opts...)
+			tt.checkError(t, err)
+			if !reflect.DeepEqual(tt.v, tt.wantValue) {
+				t.Errorf("Convert() got = %v, want %v", tt.v, tt.wantValue)
+				t.Fail()
+			}
+		})
+	}
+}
+
+func TestConvertInvalidTags(t *testing.T) {
+	mpf := &multipart.Form{Value: map[string][]string{"v": {"1"}}}
+
+	tests := []struct {
+		name string
+		v    any
+	}{
+		{"unknown option", &struct {
+			V int `form:"v,bogus"`
+		}{}},
+		{"unit on non-duration", &struct {
+			V int `form:"v,unit=s"`
+		}{}},
+		{"unknown multi-value policy", &struct {
+			V string `form:"v,multi=bogus"`
+		}{}},
+		{"same decimal and group separators", &struct {
+			V float64 `form:"v,decimal=.,group=."`
+		}{}},
+		{"option on unsent field", &struct {
+			V int    `form:"v"`
+			W string `form:"w,layout=2006-01-02"`
+		}{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Convert(mpf, tt.v)
+			if !errors.Is(err, ErrInvalidTag) {
+				t.Fatal("unexpected error:", err)
+			}
+		})
+	}
+}
+
+func TestSelectValueUnknownPolicy(t *testing.T) {
+	f := field{name: "ID", key: "id", path: "ID", typ: reflect.TypeFor[int](), opts: "multi=eror"}
+	_, err := newConfig(nil).selectValue(f, []string{"1", "2"})
+	if !errors.Is(err, ErrInvalidTag) {
+		t.Fatal("unexpected error:", err)
+	}
+}
+
+func TestUnknownFieldsReport(t *testing.T) {
+	mpf := &multipart.Form{
+		Value: map[string][]string{"name": {"John"}, "nmae": {"Jane"}},
+	}
+
+	var report []ErrUnknownFields
+	var v struct {
+		Name string `form:"name"`
+	}
+	err := Convert(mpf, &v, WithUnknownFieldsReport(func(u ErrUnknownFields) {
+		report = append(report, u)
+	}))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	want := []ErrUnknownFields{{Values: []string{"nmae"}}}
+	if !reflect.DeepEqual(report, want) || v.Name != "John" {
+		t.Errorf("report got = %+v, want %+v", report, want)
+	}
+}
+
+func TestDeprecatedAliasReport(t *testing.T) {
+	mpf := &multipart.Form{
+		Value: map[string][]string{"mail": {"john@example.com"}, "name": {"John"}},
+	}
+
+	var used []string
+	var v struct {
+		Email string `form:"email|mail"`
+		Name  string `form:"name|full_name"`
+	}
+	err := Convert(mpf, &v, WithDeprecatedAliasReport(func(key, alias string) {
+		used = append(used, key+"<-"+alias)
+	}))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	if want := []string{"email<-mail"}; !reflect.DeepEqual(used, want) || v.Email != "john@example.com" {
+		t.Errorf("report got = %v, want %v", used, want)
+	}
+}
+
+func TestPresence(t *testing.T) {
+	mpf := &multipart.Form{
+		Value: map[string][]string{
+			"name":  {"John"},
+			"age":   {""},
+			"email": {""},
+			"extra": {"x"},
+		},
+		File: map[string][]*multipart.FileHeader{
+			"photo": {{Filename: "photo.png"}},
+		},
+	}
+
+	type Body struct {
+		Name     string                `form:"name"`
+		Age      *int                  `form:"age"`
+		Email    string                `form:"email"`
+		Phone    string                `form:"phone"`
+		Photo    *multipart.FileHeader `form:"photo"`
+		Extra    map[string][]string   `form:",remaining"`
+		Presence Presence
+	}
+
+	var v Body
+	err := Convert(mpf, &v, WithEmptyPolicy(EmptyAsAbsent))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	want := Presence{
+		Keys:  []string{"name", "photo", "Extra"},
+		Paths: []string{"Name", "Photo", "Extra"},
+	}
+	if !reflect.DeepEqual(v.Presence, want) {
+		t.Errorf("presence got = %+v, want %+v", v.Presence, want)
+	}
+	if !v.Presence.Has("Name") || v.Presence.Has("Age") || !v.Presence.HasKey("photo") || v.Presence.HasKey("phone") {
+		t.Errorf("presence got = %+v", v.Presence)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func TestTagLists(t *testing.T) {
+	tests := []struct {
+		name string
+		list []string
+		keys []string
+	}{
+		{"multi", tags.MultiPolicies, mapKeys(multiValuePolicies)},
+		{"unit", tags.DurationUnits, mapKeys(durationUnits)},
+		{"mod", tags.Modifiers, mapKeys(modifiers)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			list := slices.Clone(tt.list)
+			slices.Sort(list)
+			if !slices.Equal(list, tt.keys) {
+				t.Errorf("tags list got = %v, want %v", list, tt.keys)
+			}
+		})
+	}
+}
+
+func mapKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
 }
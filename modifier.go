@@ -0,0 +1,107 @@
+package m2s
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Modifier rewrites a raw form value before it is converted, e.g. to trim
+// stray whitespace that would make number parsing fail. Modifiers are
+// listed in the mod struct tag, as in `mod:"trim,lower"`, and run in order
+// on every value sent for a key, before one is selected.
+type Modifier func(string) string
+
+// modifiers are the modifiers available without WithModifier.
+var modifiers = map[string]Modifier{
+	"trim":          strings.TrimSpace,
+	"lower":         strings.ToLower,
+	"upper":         strings.ToUpper,
+	"collapse":      collapseSpace,
+	"strip_control": stripControl,
+}
+
+// modifierNames splits the mod tag of a field into modifier names.
+func modifierNames(tag string) []string {
+	if tag == "" {
+		return nil
+	}
+	return strings.Split(tag, ",")
+}
+
+// checkModifiers reports the first modifier of f that is not registered.
+func (c *config) checkModifiers(f field) error {
+	for _, name := range f.mods {
+		if _, ok := c.modifier(name); !ok {
+			return fieldError(f, fmt.Errorf("%w: unknown modifier %q", ErrInvalidTag, name))
+		}
+	}
+	return nil
+}
+
+func (c *config) modifier(name string) (Modifier, bool) {
+	if m, ok := c.modifiers[name]; ok {
+		return m, true
+	}
+	m, ok := modifiers[name]
+	return m, ok
+}
+
+// modify applies the modifiers of f to formValue.
+func (c *config) modify(f field, formValue string) (string, error) {
+	for _, name := range f.mods {
+		m, ok := c.modifier(name)
+		if !ok {
+			return "", fieldError(f, fmt.Errorf("%w: unknown modifier %q", ErrInvalidTag, name))
+		}
+		formValue = m(formValue)
+	}
+	return formValue, nil
+}
+
+// modifyAll applies the modifiers of f to each of formValues. It returns a
+// new slice, leaving the values of the form untouched.
+func (c *config) modifyAll(f field, formValues []string) ([]string, error) {
+	if len(f.mods) == 0 {
+		return formValues, nil
+	}
+	out := make([]string, len(formValues))
+	for i, formValue := range formValues {
+		var err error
+		out[i], err = c.modify(f, formValue)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return out, nil
+}
+
+// collapseSpace replaces each run of whitespace in s with a single space.
+func collapseSpace(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	space := false
+	for _, r := range s {
+		if unicode.IsSpace(r) {
+			if !space {
+				b.WriteByte(' ')
+			}
+			space = true
+			continue
+		}
+		space = false
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// stripControl removes control characters from s, except tabs and line
+// breaks, which textarea inputs legitimately send.
+func stripControl(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) && r != '\t' && r != '\n' && r != '\r' {
+			return -1
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,63 @@
+package m2s
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+)
+
+// MultiValuePolicy tells which value Convert uses when a key is sent more
+// than once.
+type MultiValuePolicy uint
+
+const (
+	// MultiFirstNonEmpty uses the first value that is not empty. This is
+	// the default.
+	MultiFirstNonEmpty MultiValuePolicy = iota
+	// MultiFirst uses the first value.
+	MultiFirst
+	// MultiLast uses the last value.
+	MultiLast
+	// MultiJoin joins all values with the separator set by
+	// WithJoinSeparator, "," by default.
+	MultiJoin
+	// MultiError fails with ErrDuplicateValue.
+	MultiError
+)
+
+// multiValuePolicies maps the values of the multi tag option to policies.
+var multiValuePolicies = map[string]MultiValuePolicy{
+	"nonempty": MultiFirstNonEmpty,
+	"first":    MultiFirst,
+	"last":     MultiLast,
+	"join":     MultiJoin,
+	"error":    MultiError,
+}
+
+// selectValue picks the value of f from formValues, which is never empty,
+// following the multi tag option of f or the policy of c.
+func (c *config) selectValue(f field, formValues []string) (string, error) {
+	policy := c.multiValuePolicy
+	if name, ok := f.opts.Lookup("multi"); ok {
+		policy, ok = multiValuePolicies[name]
+		if !ok {
+			return "", fieldError(f, fmt.Errorf("%w: unknown multi-value policy %q", ErrInvalidTag, name))
+		}
+	}
+
+	switch policy {
+	case MultiFirst:
+		return formValues[0], nil
+	case MultiLast:
+		return formValues[len(formValues)-1], nil
+	case MultiJoin:
+		return strings.Join(formValues, c.joinSeparator), nil
+	case MultiError:
+		if len(formValues) > 1 {
+			return "", parseError(f, f.typ, CodeDuplicateValue, strings.Join(formValues, c.joinSeparator), ErrDuplicateValue)
+		}
+		return formValues[0], nil
+	default:
+		return cmp.Or(formValues...), nil
+	}
+}
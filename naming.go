@@ -0,0 +1,124 @@
+package m2s
+
+import (
+	"mime/multipart"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+// SnakeCase converts a Go field name to snake_case, e.g. "UserID" to
+// "user_id". Use it with WithKeyNaming.
+func SnakeCase(name string) string {
+	return strings.ToLower(strings.Join(splitWords(name), "_"))
+}
+
+// KebabCase converts a Go field name to kebab-case, e.g. "UserID" to
+// "user-id". Use it with WithKeyNaming.
+func KebabCase(name string) string {
+	return strings.ToLower(strings.Join(splitWords(name), "-"))
+}
+
+// CamelCase converts a Go field name to camelCase, e.g. "UserID" to
+// "userId". Use it with WithKeyNaming.
+func CamelCase(name string) string {
+	words := splitWords(name)
+	for i, w := range words {
+		w = strings.ToLower(w)
+		if i > 0 {
+			r := []rune(w)
+			r[0] = unicode.ToUpper(r[0])
+			w = string(r)
+		}
+		words[i] = w
+	}
+	return strings.Join(words, "")
+}
+
+// splitWords splits a Go identifier into words at case changes, keeping
+// acronyms together: "HTTPServerID" becomes "HTTP", "Server", "ID".
+func splitWords(name string) []string {
+	var words []string
+	r := []rune(name)
+	start := 0
+	for i := 1; i < len(r); i++ {
+		switch {
+		case r[i] == '_':
+			if start < i {
+				words = append(words, string(r[start:i]))
+			}
+			start = i + 1
+		case unicode.IsUpper(r[i]) && (unicode.IsLower(r[i-1]) || unicode.IsDigit(r[i-1])),
+			unicode.IsUpper(r[i]) && unicode.IsUpper(r[i-1]) && i+1 < len(r) && unicode.IsLower(r[i+1]):
+			if start < i {
+				words = append(words, string(r[start:i]))
+			}
+			start = i
+		}
+	}
+	if start < len(r) {
+		words = append(words, string(r[start:]))
+	}
+	return words
+}
+
+// keyName returns the form key of a field without a name in its tag.
+func (c *config) keyName(name string) string {
+	if c.keyNaming == nil {
+		return name
+	}
+	return c.keyNaming(name)
+}
+
+// foldForm returns a copy of mpf whose keys that match the key of a field
+// case-insensitively are renamed to that key, so that the rest of decoding
+// can use exact matches. Values of keys that match exactly come first.
+func foldForm(mpf *multipart.Form, fields []field) *multipart.Form {
+	return &multipart.Form{
+		Value: foldKeys(mpf.Value, fields, func(f field) bool { return f.kind == FieldValue }),
+		File:  foldKeys(mpf.File, fields, func(f field) bool { return f.kind != FieldValue }),
+	}
+}
+
+func foldKeys[V any](m map[string][]V, fields []field, use func(field) bool) map[string][]V {
+	folded := make(map[string][]V, len(m))
+	var renamed []string
+	for key, values := range m {
+		if to := foldKey(key, fields, use); to != key {
+			renamed = append(renamed, key)
+			continue
+		}
+		folded[key] = append(folded[key], values...)
+	}
+	slices.Sort(renamed)
+	for _, key := range renamed {
+		to := foldKey(key, fields, use)
+		folded[to] = append(folded[to], m[key]...)
+	}
+	return folded
+}
+
+// foldKey returns the key of the first field accepted by use that matches
+// key case-insensitively, with the index of indexed array keys kept, or
+// key itself if there is none.
+func foldKey(key string, fields []field, use func(field) bool) string {
+	for _, f := range fields {
+		if use(f) && f.usesKey(key) {
+			return key
+		}
+	}
+	for _, f := range fields {
+		if !use(f) || isRemaining(f) {
+			continue
+		}
+		for _, name := range f.keys() {
+			if len(key) < len(name) || !strings.EqualFold(key[:len(name)], name) {
+				continue
+			}
+			if candidate := name + key[len(name):]; f.usesKey(candidate) {
+				return candidate
+			}
+		}
+	}
+	return key
+}
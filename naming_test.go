@@ -0,0 +1,32 @@
+package m2s
+
+import "testing"
+
+func TestKeyNaming(t *testing.T) {
+	tests := []struct {
+		name              string
+		snake, kebab, cml string
+	}{
+		{"Name", "name", "name", "name"},
+		{"FirstName", "first_name", "first-name", "firstName"},
+		{"UserID", "user_id", "user-id", "userId"},
+		{"HTTPServerPort", "http_server_port", "http-server-port", "httpServerPort"},
+		{"Address2Line", "address2_line", "address2-line", "address2Line"},
+		{"Legacy_Field", "legacy_field", "legacy-field", "legacyField"},
+		{"ID", "id", "id", "id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SnakeCase(tt.name); got != tt.snake {
+				t.Errorf("SnakeCase() got = %q, want %q", got, tt.snake)
+			}
+			if got := KebabCase(tt.name); got != tt.kebab {
+				t.Errorf("KebabCase() got = %q, want %q", got, tt.kebab)
+			}
+			if got := CamelCase(tt.name); got != tt.cml {
+				t.Errorf("CamelCase() got = %q, want %q", got, tt.cml)
+			}
+		})
+	}
+}
@@ -0,0 +1,285 @@
+package m2s
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// NumberFormat describes how numbers are written in form values, e.g.
+// "1.234,56" by European users. The zero value is the format strconv
+// parses. It is set for every field with WithNumberFormat; the decimal,
+// group, underscores, prefix, percent and currency tag options override it
+// for a single field.
+type NumberFormat struct {
+	Decimal     string // decimal separator; "." if empty
+	Group       string // grouping separator before groups of three digits; "space" means any space
+	Underscores bool   // allow underscores between digits, as in 1_000_000
+	Prefixes    bool   // allow the 0x, 0o and 0b prefixes on integers
+	Percent     bool   // read floats as percentages, so "12.5%" and "12.5" become 0.125
+	Currency    bool   // strip currency symbols and codes around the number, e.g. "€" or "EUR"
+}
+
+// isNumberType reports whether rt is an integer or float type, a pointer to
+// one or a slice or array of either. time.Duration is not a number here.
+func isNumberType(rt reflect.Type) bool {
+	rt = numberElem(rt)
+	if rt == durationType {
+		return false
+	}
+	return isIntegerKind(rt.Kind()) || isFloatKind(rt.Kind())
+}
+
+// numberElem returns rt without pointers or, for slices and arrays, their
+// element type without pointers.
+func numberElem(rt reflect.Type) reflect.Type {
+	rt = indirectType(rt)
+	if rt.Kind() == reflect.Slice || rt.Kind() == reflect.Array {
+		rt = indirectType(rt.Elem())
+	}
+	return rt
+}
+
+func isIntegerKind(k reflect.Kind) bool {
+	switch k {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	default:
+		return false
+	}
+}
+
+func isFloatKind(k reflect.Kind) bool {
+	return k == reflect.Float32 || k == reflect.Float64
+}
+
+// numberFormat returns the number format of f: the one of c with the tag
+// options of f applied.
+func (c *config) numberFormat(f field) NumberFormat {
+	nf := c.numbers
+	if sep, ok := f.opts.Lookup("decimal"); ok {
+		nf.Decimal = sep
+	}
+	if sep, ok := f.opts.Lookup("group"); ok {
+		nf.Group = sep
+	}
+	nf.Underscores = nf.Underscores || f.opts.Contains("underscores")
+	nf.Prefixes = nf.Prefixes || f.opts.Contains("prefix")
+	nf.Percent = nf.Percent || f.opts.Contains("percent")
+	nf.Currency = nf.Currency || f.opts.Contains("currency")
+	return nf
+}
+
+// normalize rewrites formValue, written in the format nf, into a number
+// strconv parses in the returned base.
+func (nf NumberFormat) normalize(formValue string, integer bool) (string, int, error) {
+	err := nf.checkSeparators()
+	if err != nil {
+		return "", 0, err
+	}
+
+	s := formValue
+	if nf.Currency {
+		s = trimCurrency(s)
+	}
+	if nf.Percent && !integer {
+		s = strings.TrimSpace(strings.TrimSuffix(s, "%"))
+	}
+	s, err = nf.removeGroups(s, integer)
+	if err != nil {
+		return "", 0, err
+	}
+	if nf.Decimal != "" && nf.Decimal != "." && !integer {
+		if strings.Contains(s, ".") {
+			return "", 0, fmt.Errorf("unexpected \".\", the decimal separator is %q", nf.Decimal)
+		}
+		s = strings.Replace(s, nf.Decimal, ".", 1)
+	}
+	if nf.Underscores {
+		s = removeUnderscores(s)
+	}
+
+	base := 10
+	if nf.Prefixes && integer {
+		sign, digits := "", s
+		if digits != "" && (digits[0] == '+' || digits[0] == '-') {
+			sign, digits = digits[:1], digits[1:]
+		}
+		if len(digits) > 2 && digits[0] == '0' {
+			switch digits[1] {
+			case 'x', 'X':
+				base = 16
+			case 'o', 'O':
+				base = 8
+			case 'b', 'B':
+				base = 2
+			}
+			if base != 10 {
+				s = sign + digits[2:]
+			}
+		}
+	}
+	return s, base, nil
+}
+
+// checkSeparators reports an error if the decimal and group separators of
+// nf are the same, which would silently turn "1.5" into 15.
+func (nf NumberFormat) checkSeparators() error {
+	if nf.Group == cmp.Or(nf.Decimal, ".") {
+		return fmt.Errorf("decimal and group separators are both %q", nf.Group)
+	}
+	return nil
+}
+
+// removeGroups removes the group separators from s. They may only appear
+// in the integer part, with one to three digits before the first one and
+// exactly three after each, as in 1.234.567, so that "1.5" fails instead
+// of becoming 15 when the decimal separator is ",".
+func (nf NumberFormat) removeGroups(s string, integer bool) (string, error) {
+	if nf.Group == "" {
+		return s, nil
+	}
+	split := func(s string) []string { return strings.Split(s, nf.Group) }
+	if nf.Group == "space" {
+		s = strings.TrimFunc(s, isSpace)
+		split = splitSpace
+	}
+
+	whole, frac := s, ""
+	if !integer {
+		if i := strings.Index(s, cmp.Or(nf.Decimal, ".")); i >= 0 {
+			whole, frac = s[:i], s[i:]
+		}
+	}
+	if len(split(frac)) > 1 {
+		return "", fmt.Errorf("group separator %q after the decimal separator", nf.Group)
+	}
+	groups := split(whole)
+	if len(groups) == 1 {
+		return s, nil
+	}
+	for i, group := range groups {
+		if i == 0 {
+			group = strings.TrimLeft(group, "+-")
+		}
+		if len(group) != 3 && (i > 0 || len(group) == 0 || len(group) > 3) {
+			return "", fmt.Errorf("misplaced group separator %q", nf.Group)
+		}
+	}
+	return strings.Join(groups, "") + frac, nil
+}
+
+// splitSpace splits s at each space.
+func splitSpace(s string) []string {
+	var parts []string
+	start := 0
+	for i, r := range s {
+		if isSpace(r) {
+			parts = append(parts, s[start:i])
+			start = i + utf8.RuneLen(r)
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// plain reports whether nf reads numbers exactly as strconv does, so that
+// they can be documented as numbers. Integers ignore the decimal
+// separator.
+func (nf NumberFormat) plain(integer bool) bool {
+	if integer || nf.Decimal == "." {
+		nf.Decimal = ""
+	}
+	return nf == NumberFormat{}
+}
+
+// formatFloat is the inverse of normalize for floats. It writes no group
+// separators, which normalize does not require.
+func (nf NumberFormat) formatFloat(v float64, bitSize int) string {
+	s := strconv.FormatFloat(v, 'f', -1, bitSize)
+	if nf.Percent && !math.IsInf(v, 0) && !math.IsNaN(v) {
+		s = shiftPoint(s)
+	}
+	if nf.Decimal != "" && nf.Decimal != "." {
+		s = strings.Replace(s, ".", nf.Decimal, 1)
+	}
+	return s
+}
+
+// shiftPoint multiplies s, a float formatted by strconv with 'f', by 100
+// without the rounding errors of float arithmetic.
+func shiftPoint(s string) string {
+	sign := ""
+	if strings.HasPrefix(s, "-") {
+		sign, s = "-", s[1:]
+	}
+	whole, frac, _ := strings.Cut(s, ".")
+	frac += "00"
+	whole = strings.TrimLeft(whole+frac[:2], "0")
+	frac = strings.TrimRight(frac[2:], "0")
+	if whole == "" {
+		whole = "0"
+	}
+	if frac != "" {
+		whole += "." + frac
+	}
+	return sign + whole
+}
+
+// removeUnderscores removes the underscores that separate two digits.
+// Hex digits count, so that 0xFF_FF works with the prefix option.
+func removeUnderscores(s string) string {
+	var b strings.Builder
+	b.Grow(len(s))
+	for i := range len(s) {
+		if s[i] == '_' && i > 0 && i < len(s)-1 && isHexDigit(s[i-1]) && isHexDigit(s[i+1]) {
+			continue
+		}
+		b.WriteByte(s[i])
+	}
+	return b.String()
+}
+
+func isHexDigit(c byte) bool {
+	return '0' <= c && c <= '9' || 'a' <= c && c <= 'f' || 'A' <= c && c <= 'F'
+}
+
+// trimCurrency removes currency symbols, e.g. "€", and a three-letter
+// ISO 4217 code separated by a space, e.g. "EUR 12" or "12 EUR", from the
+// ends of s. Other letters are kept, so "12 apples" still fails to parse.
+func trimCurrency(s string) string {
+	s = strings.TrimFunc(s, func(r rune) bool {
+		return unicode.Is(unicode.Sc, r) || isSpace(r)
+	})
+	if len(s) > 4 && isCurrencyCode(s[:3]) {
+		if r, size := utf8.DecodeRuneInString(s[3:]); isSpace(r) {
+			s = strings.TrimLeftFunc(s[3+size:], isSpace)
+		}
+	}
+	if n := len(s); n > 4 && isCurrencyCode(s[n-3:]) {
+		if r, size := utf8.DecodeLastRuneInString(s[:n-3]); isSpace(r) {
+			s = strings.TrimRightFunc(s[:n-3-size], isSpace)
+		}
+	}
+	return s
+}
+
+func isCurrencyCode(s string) bool {
+	for i := range len(s) {
+		if s[i] < 'A' || s[i] > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// isSpace reports whether r is a space, including the narrow no-break
+// space that French uses to group digits.
+func isSpace(r rune) bool {
+	return unicode.IsSpace(r) || r == '\u202f'
+}
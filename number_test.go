@@ -0,0 +1,62 @@
+package m2s
+
+import "testing"
+
+func TestTrimCurrency(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"€ 1.234,56", "1.234,56"},
+		{"1.234,56 €", "1.234,56"},
+		{"$5", "5"},
+		{"EUR 12", "12"},
+		{"12 USD", "12"},
+		{"12 CHF", "12"},
+		{"0xFF", "0xFF"},
+		{"12 apples", "12 apples"},
+		{"12EUR", "12EUR"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			if got := trimCurrency(tt.in); got != tt.want {
+				t.Errorf("trimCurrency() got = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeSeparators(t *testing.T) {
+	tests := []struct {
+		name    string
+		nf      NumberFormat
+		in      string
+		integer bool
+		want    string
+		wantErr bool
+	}{
+		{"same separators", NumberFormat{Decimal: ",", Group: ","}, "1,5", false, "", true},
+		{"group equals default decimal", NumberFormat{Group: "."}, "1.5", false, "", true},
+		{"group equals default decimal on integer", NumberFormat{Group: "."}, "1.000", true, "", true},
+		{"group on integer", NumberFormat{Decimal: ",", Group: "."}, "1.000", true, "1000", false},
+		{"different separators", NumberFormat{Decimal: ",", Group: "."}, "1.000,5", false, "1000.5", false},
+		{"groups of three", NumberFormat{Decimal: ",", Group: "."}, "-12.345.678,5", false, "-12345678.5", false},
+		{"no groups", NumberFormat{Decimal: ",", Group: "."}, "12345,5", false, "12345.5", false},
+		{"short group on integer", NumberFormat{Decimal: ",", Group: "."}, "1.5", true, "", true},
+		{"short group", NumberFormat{Decimal: ",", Group: "."}, "1.5,0", false, "", true},
+		{"long first group", NumberFormat{Decimal: ",", Group: "."}, "1234.567", true, "", true},
+		{"empty first group", NumberFormat{Decimal: ",", Group: "."}, ".123", true, "", true},
+		{"group after decimal", NumberFormat{Decimal: ",", Group: "."}, "1,000.5", false, "", true},
+		{"space groups", NumberFormat{Decimal: ",", Group: "space"}, " 1 234\u202f567,5 ", false, "1234567.5", false},
+		{"double space", NumberFormat{Decimal: ",", Group: "space"}, "1  234", true, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, _, err := tt.nf.normalize(tt.in, tt.integer)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("normalize() got = %q, %v, want %q, error %v", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package m2s
+
+import (
+	"cmp"
+	"encoding"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// RequestBody is an OpenAPI 3 request body object.
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// MediaType is an OpenAPI 3 media type object.
+type MediaType struct {
+	Schema   *Schema                 `json:"schema"`
+	Encoding map[string]PartEncoding `json:"encoding,omitempty"`
+}
+
+// PartEncoding is an OpenAPI 3 encoding object, describing how a single
+// part of a multipart/form-data body is encoded.
+type PartEncoding struct {
+	ContentType string `json:"contentType"`
+}
+
+// Schema is the subset of the OpenAPI 3 schema object m2s generates.
+type Schema struct {
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	MinItems             *int               `json:"minItems,omitempty"`
+	MaxItems             *int               `json:"maxItems,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+}
+
+// OpenAPISchema returns the multipart/form-data request body that Convert
+// accepts for rt, which must be a struct type or a pointer to one. The
+// result can be marshaled with encoding/json.
+//
+// File fields are binary strings, TextUnmarshaler fields are strings and
+// struct, slice and map fields are described by their JSON schema with an
+// application/json content type. Options that change form keys, such as
+// WithKeyNaming, should match those passed to Convert.
+func OpenAPISchema(rt reflect.Type, opts ...Option) (*RequestBody, error) {
+	if rt.Kind() == reflect.Pointer {
+		rt = rt.Elem()
+	}
+	if rt.Kind() != reflect.Struct {
+		return nil, ErrValueMustBeStruct
+	}
+
+	schema := &Schema{
+		Type:       "object",
+		Properties: make(map[string]*Schema),
+	}
+	mediaType := MediaType{
+		Schema: schema,
+	}
+
+	c := newConfig(opts)
+	for _, f := range c.structFields(rt) {
+		err := c.validateField(f)
+		if err != nil {
+			return nil, err
+		}
+
+		if isRemaining(f) {
+			s := &Schema{Type: "string"}
+			if remainingElem(f.typ) == remainingFilesType {
+				s = binarySchema()
+			}
+			if schema.AdditionalProperties != nil && !reflect.DeepEqual(schema.AdditionalProperties, s) {
+				s = &Schema{} // both values and files
+			}
+			schema.AdditionalProperties = s
+			continue
+		}
+
+		if f.typ == presenceType {
+			continue
+		}
+
+		switch f.kind {
+		case FieldFile:
+			schema.Properties[f.key] = binarySchema()
+			continue
+		case FieldFiles:
+			schema.Properties[f.key] = &Schema{Type: "array", Items: binarySchema()}
+			continue
+		}
+
+		f = f.withoutOptional()
+		s, isJSON := valueSchema(f.typ)
+		switch {
+		case isSplit(f):
+			s, isJSON = &Schema{Type: "string"}, false // a single delimited part
+		case indirectType(f.typ) == timeType:
+			s = timeSchema(f)
+		case isDurationType(f.typ):
+			s = durationSchema(f)
+		case !isJSON && isNumberType(f.typ) && !c.numberFormat(f).plain(isIntegerKind(numberElem(f.typ).Kind())):
+			s = &Schema{Type: "string"} // e.g. "1.234,56", "12.5%" or "0xFF"
+		}
+		schema.Properties[f.key] = s
+
+		if isJSON {
+			if mediaType.Encoding == nil {
+				mediaType.Encoding = make(map[string]PartEncoding)
+			}
+			mediaType.Encoding[f.key] = PartEncoding{ContentType: "application/json"}
+		}
+	}
+
+	return &RequestBody{
+		Content: map[string]MediaType{
+			"multipart/form-data": mediaType,
+		},
+	}, nil
+}
+
+// valueSchema returns the schema of a form value of type rt and whether the
+// value is decoded as JSON.
+func valueSchema(rt reflect.Type) (*Schema, bool) {
+	if elem := optionalElem(rt); elem != nil {
+		return valueSchema(elem)
+	}
+	if reflect.PointerTo(rt).Implements(reflect.TypeFor[encoding.TextUnmarshaler]()) {
+		return textSchema(rt), false
+	}
+
+	switch rt.Kind() {
+	case reflect.Pointer:
+		return valueSchema(rt.Elem())
+	case reflect.Struct, reflect.Slice, reflect.Array, reflect.Map:
+		return jsonSchema(rt, make(map[reflect.Type]bool)), true
+	default:
+		return primitiveSchema(rt), false
+	}
+}
+
+// jsonSchema returns the schema of rt as encoding/json would decode it.
+// seen guards against recursive types.
+func jsonSchema(rt reflect.Type, seen map[reflect.Type]bool) *Schema {
+	if reflect.PointerTo(rt).Implements(reflect.TypeFor[encoding.TextUnmarshaler]()) {
+		return textSchema(rt)
+	}
+
+	switch rt.Kind() {
+	case reflect.Pointer:
+		return jsonSchema(rt.Elem(), seen)
+	case reflect.Slice:
+		if rt.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: jsonSchema(rt.Elem(), seen)}
+	case reflect.Array:
+		n := rt.Len()
+		return &Schema{Type: "array", Items: jsonSchema(rt.Elem(), seen), MinItems: &n, MaxItems: &n}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: jsonSchema(rt.Elem(), seen)}
+	case reflect.Struct:
+		s := &Schema{Type: "object"}
+		if seen[rt] {
+			return s
+		}
+		seen[rt] = true
+		defer delete(seen, rt)
+
+		for i := range rt.NumField() {
+			sf := rt.Field(i)
+			tag := sf.Tag.Get("json")
+			if !sf.IsExported() || tag == "-" {
+				continue
+			}
+			name, _, _ := strings.Cut(tag, ",")
+			if s.Properties == nil {
+				s.Properties = make(map[string]*Schema)
+			}
+			s.Properties[cmp.Or(name, sf.Name)] = jsonSchema(sf.Type, seen)
+		}
+		return s
+	case reflect.Interface:
+		return &Schema{}
+	default:
+		return primitiveSchema(rt)
+	}
+}
+
+func primitiveSchema(rt reflect.Type) *Schema {
+	switch rt.Kind() {
+	case reflect.Int, reflect.Int64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Int8, reflect.Int16, reflect.Int32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Uint, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64", Minimum: new(float64)}
+	case reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32", Minimum: new(float64)}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	default:
+		return &Schema{Type: "string"}
+	}
+}
+
+func textSchema(rt reflect.Type) *Schema {
+	if rt == reflect.TypeFor[time.Time]() {
+		return &Schema{Type: "string", Format: "date-time"}
+	}
+	return &Schema{Type: "string"}
+}
+
+// timeSchema returns the schema of a time.Time field, taking its time tag
+// options into account.
+func timeSchema(f field) *Schema {
+	if f.opts.Contains("unix") || f.opts.Contains("unixmilli") {
+		return &Schema{Type: "integer", Format: "int64"}
+	}
+	switch layout, _ := f.opts.Lookup("layout"); layout {
+	case "", time.RFC3339:
+		return &Schema{Type: "string", Format: "date-time"}
+	case time.DateOnly:
+		return &Schema{Type: "string", Format: "date"}
+	default:
+		return &Schema{Type: "string"}
+	}
+}
+
+// durationSchema returns the schema of a time.Duration field or a slice of
+// durations: strings such as "90s", or numbers with the unit tag option.
+func durationSchema(f field) *Schema {
+	s := &Schema{Type: "string"}
+	if _, ok := f.opts.Lookup("unit"); ok {
+		s = &Schema{Type: "number"}
+	}
+	switch rt := indirectType(f.typ); rt.Kind() {
+	case reflect.Slice:
+		return &Schema{Type: "array", Items: s}
+	case reflect.Array:
+		n := rt.Len()
+		return &Schema{Type: "array", Items: s, MinItems: &n, MaxItems: &n}
+	}
+	return s
+}
+
+func binarySchema() *Schema {
+	return &Schema{Type: "string", Format: "binary"}
+}
@@ -0,0 +1,206 @@
+package m2s
+
+import (
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestOpenAPISchema(t *testing.T) {
+	type Address struct {
+		City string `json:"city"`
+		Zip  *int   `json:"zip,omitempty"`
+		Skip string `json:"-"`
+	}
+
+	type Body struct {
+		Name     string                  `form:"name"`
+		Age      *int32                  `form:"age"`
+		Score    float64                 `form:"score"`
+		Count    uint                    `form:"count"`
+		Active   bool                    `form:"active"`
+		Birthday time.Time               `form:"birthday"`
+		Address  Address                 `form:"address"`
+		Tags     []string                `form:"tags"`
+		Meta     map[string]int          `form:"meta"`
+		Photo    *multipart.FileHeader   `form:"photo"`
+		Files    []*multipart.FileHeader `form:"files"`
+		Ignored  string                  `form:"-"`
+	}
+
+	got, err := OpenAPISchema(reflect.TypeFor[*Body]())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	b, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"content":{"multipart/form-data":{"schema":{"type":"object","properties":{` +
+		`"active":{"type":"boolean"},` +
+		`"address":{"type":"object","properties":{"city":{"type":"string"},"zip":{"type":"integer","format":"int64"}}},` +
+		`"age":{"type":"integer","format":"int32"},` +
+		`"birthday":{"type":"string","format":"date-time"},` +
+		`"count":{"type":"integer","format":"int64","minimum":0},` +
+		`"files":{"type":"array","items":{"type":"string","format":"binary"}},` +
+		`"meta":{"type":"object","additionalProperties":{"type":"integer","format":"int64"}},` +
+		`"name":{"type":"string"},` +
+		`"photo":{"type":"string","format":"binary"},` +
+		`"score":{"type":"number","format":"double"},` +
+		`"tags":{"type":"array","items":{"type":"string"}}` +
+		`}},"encoding":{` +
+		`"address":{"contentType":"application/json"},` +
+		`"meta":{"contentType":"application/json"},` +
+		`"tags":{"contentType":"application/json"}` +
+		`}}}}`
+
+	if string(b) != want {
+		t.Errorf("OpenAPISchema() got = %s, want %s", b, want)
+	}
+}
+
+func TestOpenAPISchemaRecursiveType(t *testing.T) {
+	type Node struct {
+		Name     string  `json:"name"`
+		Children []*Node `json:"children"`
+	}
+
+	got, err := OpenAPISchema(reflect.TypeFor[struct {
+		Tree Node `form:"tree"`
+	}]())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	tree := got.Content["multipart/form-data"].Schema.Properties["tree"]
+	if tree.Properties["children"].Items.Type != "object" {
+		t.Errorf("unexpected schema: %+v", tree)
+	}
+}
+
+func TestOpenAPISchemaTimeOptions(t *testing.T) {
+	got, err := OpenAPISchema(reflect.TypeFor[struct {
+		Date  time.Time  `form:"date,layout=2006-01-02"`
+		Local *time.Time `form:"local,layout=2006-01-02T15:04"`
+		Stamp time.Time  `form:"stamp,unix"`
+	}]())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	props := got.Content["multipart/form-data"].Schema.Properties
+	want := map[string]*Schema{
+		"date":  {Type: "string", Format: "date"},
+		"local": {Type: "string"},
+		"stamp": {Type: "integer", Format: "int64"},
+	}
+	if !reflect.DeepEqual(props, want) {
+		t.Errorf("OpenAPISchema() got = %v, want %v", props, want)
+	}
+}
+
+func TestOpenAPISchemaDurations(t *testing.T) {
+	got, err := OpenAPISchema(reflect.TypeFor[struct {
+		Timeout   time.Duration    `form:"timeout"`
+		Retention *time.Duration   `form:"retention,unit=s"`
+		Intervals []time.Duration  `form:"intervals"`
+		Window    [2]time.Duration `form:"window"`
+	}]())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	props := got.Content["multipart/form-data"].Schema.Properties
+	two := 2
+	want := map[string]*Schema{
+		"timeout":   {Type: "string"},
+		"retention": {Type: "number"},
+		"intervals": {Type: "array", Items: &Schema{Type: "string"}},
+		"window":    {Type: "array", Items: &Schema{Type: "string"}, MinItems: &two, MaxItems: &two},
+	}
+	if !reflect.DeepEqual(props, want) {
+		t.Errorf("OpenAPISchema() got = %v, want %v", props, want)
+	}
+}
+
+func TestOpenAPISchemaNumberFormat(t *testing.T) {
+	got, err := OpenAPISchema(reflect.TypeFor[struct {
+		Price    float64 `form:"price"`
+		Count    int     `form:"count"`
+		Rate     float64 `form:"rate,decimal=.,group=,"`
+		Plain    float64 `form:"plain,decimal=."`
+		Discount float64 `form:"discount,decimal=.,percent"`
+		Mask     uint32  `form:"mask,prefix"`
+		Total    int     `form:"total,currency"`
+	}](), WithNumberFormat(NumberFormat{Decimal: ","}))
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	props := got.Content["multipart/form-data"].Schema.Properties
+	want := map[string]*Schema{
+		"price":    {Type: "string"},
+		"count":    {Type: "integer", Format: "int64"},
+		"rate":     {Type: "string"},
+		"plain":    {Type: "number", Format: "double"},
+		"discount": {Type: "string"},
+		"mask":     {Type: "string"},
+		"total":    {Type: "string"},
+	}
+	if !reflect.DeepEqual(props, want) {
+		t.Errorf("OpenAPISchema() got = %v, want %v", props, want)
+	}
+}
+
+func TestOpenAPISchemaArrays(t *testing.T) {
+	got, err := OpenAPISchema(reflect.TypeFor[struct {
+		RGB [3]float64 `form:"rgb"`
+	}]())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	b, err := json.Marshal(got.Content["multipart/form-data"].Schema.Properties["rgb"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"type":"array","items":{"type":"number","format":"double"},"minItems":3,"maxItems":3}`
+	if string(b) != want {
+		t.Errorf("OpenAPISchema() got = %s, want %s", b, want)
+	}
+}
+
+func TestOpenAPISchemaRemaining(t *testing.T) {
+	got, err := OpenAPISchema(reflect.TypeFor[struct {
+		Name  string              `form:"name"`
+		Extra map[string][]string `form:",remaining"`
+	}]())
+	if err != nil {
+		t.Fatal("unexpected error:", err)
+	}
+
+	schema := got.Content["multipart/form-data"].Schema
+	if _, ok := schema.Properties["Extra"]; ok || !reflect.DeepEqual(schema.AdditionalProperties, &Schema{Type: "string"}) {
+		t.Errorf("OpenAPISchema() got = %+v", schema)
+	}
+}
+
+func TestOpenAPISchemaErrors(t *testing.T) {
+	_, err := OpenAPISchema(reflect.TypeFor[string]())
+	if !errors.Is(err, ErrValueMustBeStruct) {
+		t.Fatal("unexpected error:", err)
+	}
+
+	_, err = OpenAPISchema(reflect.TypeFor[struct {
+		Fn func() `form:"fn"`
+	}]())
+	var ferr ErrField
+	if !errors.As(err, &ferr) || !errors.Is(err, ErrInvalidFieldType) || ferr.Key != "fn" {
+		t.Fatal("unexpected error:", err)
+	}
+}
@@ -0,0 +1,106 @@
+package m2s
+
+import "reflect"
+
+// Optional holds a form value together with whether it was sent. Set is
+// true if the key was sent; with WithNullAsNil, Null is true if its value
+// was the literal "null", in which case Value is the zero value. Value is
+// converted like a field of type T.
+//
+//	type PatchUser struct {
+//		Name m2s.Optional[string] `form:"name"`
+//		Age  m2s.Optional[int]    `form:"age"`
+//	}
+type Optional[T any] struct {
+	Value T
+	Set   bool
+	Null  bool
+}
+
+// Some returns a set Optional holding v.
+func Some[T any](v T) Optional[T] {
+	return Optional[T]{Value: v, Set: true}
+}
+
+// optional is implemented by *Optional[T] for every T.
+type optional interface {
+	elemType() reflect.Type
+	decodeForm(c *config, f field, formValue string) error
+	encodeForm(c *config, f field) (string, error)
+	isSet() bool
+	setZero()
+	value() reflect.Value
+}
+
+var optionalType = reflect.TypeFor[optional]()
+
+// asOptional returns rv as an optional if its type is an Optional.
+func asOptional(rv reflect.Value) (optional, bool) {
+	if !reflect.PointerTo(rv.Type()).Implements(optionalType) {
+		return nil, false
+	}
+	if !rv.CanAddr() {
+		ptrVal := reflect.New(rv.Type())
+		ptrVal.Elem().Set(rv)
+		rv = ptrVal.Elem()
+	}
+	return rv.Addr().Interface().(optional), true
+}
+
+// optionalElem returns T if rt is an Optional[T], or nil.
+func optionalElem(rt reflect.Type) reflect.Type {
+	if !reflect.PointerTo(rt).Implements(optionalType) {
+		return nil
+	}
+	return reflect.New(rt).Interface().(optional).elemType()
+}
+
+func (o *Optional[T]) elemType() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+// withoutOptional returns f with the type of its value if it is an
+// Optional, so that tag options apply to the value.
+func (f field) withoutOptional() field {
+	if elem := optionalElem(f.typ); elem != nil {
+		f.typ = elem
+	}
+	return f
+}
+
+func (o *Optional[T]) decodeForm(c *config, f field, formValue string) error {
+	f = f.withoutOptional()
+	var v Optional[T]
+	v.Set = true
+	if c.nullAsNil && formValue == "null" {
+		v.Null = true
+	} else {
+		err := c.convertValue(v.elemType(), reflect.ValueOf(&v.Value).Elem(), f, formValue)
+		if err != nil {
+			return err
+		}
+	}
+	*o = v
+	return nil
+}
+
+func (o *Optional[T]) encodeForm(c *config, f field) (string, error) {
+	if o.Null {
+		return "null", nil
+	}
+	return c.encodeValue(f.withoutOptional(), reflect.ValueOf(&o.Value).Elem())
+}
+
+func (o *Optional[T]) isSet() bool {
+	return o.Set
+}
+
+// setZero sets o to a set Optional holding the zero value of T.
+func (o *Optional[T]) setZero() {
+	*o = Optional[T]{Set: true}
+}
+
+// value returns the settable Value of o.
+func (o *Optional[T]) value() reflect.Value {
+	return reflect.ValueOf(&o.Value).Elem()
+}
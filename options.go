@@ -0,0 +1,219 @@
+package m2s
+
+import (
+	"strings"
+	"time"
+)
+
+// Option configures how Convert, Decoder and EncodeValues convert values.
+type Option func(*config)
+
+type config struct {
+	location    *time.Location
+	timeLayouts []string
+	truthyWords []string
+	falsyWords  []string
+	emptyPolicy EmptyPolicy
+	nullAsNil   bool
+
+	multiValuePolicy MultiValuePolicy
+	joinSeparator    string
+
+	strict        bool
+	reportUnknown func(ErrUnknownFields)
+
+	keyNaming       func(string) string
+	caseInsensitive bool
+
+	tagName      string
+	jsonFallback bool
+
+	reportAlias func(key, alias string)
+
+	zeroMissing bool
+	slicePolicy SlicePolicy
+
+	modifiers map[string]Modifier
+
+	numbers NumberFormat
+}
+
+func newConfig(opts []Option) *config {
+	c := &config{
+		location:    time.UTC,
+		truthyWords: DefaultTruthyWords,
+		falsyWords:  DefaultFalsyWords,
+
+		joinSeparator: ",",
+
+		tagName: "form",
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// WithLocation sets the location of times parsed with a layout that has no
+// time zone, and of Unix timestamps. The default is UTC.
+func WithLocation(loc *time.Location) Option {
+	return func(c *config) {
+		c.location = loc
+	}
+}
+
+// WithTimeLayouts sets the layouts time.Time fields without a layout tag
+// option are parsed with. They are tried in order after RFC 3339.
+func WithTimeLayouts(layouts ...string) Option {
+	return func(c *config) {
+		c.timeLayouts = layouts
+	}
+}
+
+// WithBoolWords sets the words bool fields accept in addition to the forms
+// of strconv.ParseBool, replacing DefaultTruthyWords and DefaultFalsyWords.
+// Words are compared case-insensitively.
+func WithBoolWords(truthy, falsy []string) Option {
+	return func(c *config) {
+		c.truthyWords = lower(truthy)
+		c.falsyWords = lower(falsy)
+	}
+}
+
+// WithEmptyPolicy sets how empty form values are handled. The default is
+// EmptyAsValue.
+func WithEmptyPolicy(p EmptyPolicy) Option {
+	return func(c *config) {
+		c.emptyPolicy = p
+	}
+}
+
+// WithNullAsNil makes the literal value "null" set pointer, slice and map
+// fields to nil. Fields of other types receive "null" as usual.
+func WithNullAsNil() Option {
+	return func(c *config) {
+		c.nullAsNil = true
+	}
+}
+
+// WithMultiValuePolicy sets which value is used when a key is sent more
+// than once. The default is MultiFirstNonEmpty. The multi tag option, e.g.
+// `form:"id,multi=error"`, overrides it for a single field.
+func WithMultiValuePolicy(p MultiValuePolicy) Option {
+	return func(c *config) {
+		c.multiValuePolicy = p
+	}
+}
+
+// WithJoinSeparator sets the separator MultiJoin joins values with. The
+// default is ",".
+func WithJoinSeparator(sep string) Option {
+	return func(c *config) {
+		c.joinSeparator = sep
+	}
+}
+
+// WithStrict makes Convert fail with ErrUnknownFields if the form has value
+// or file keys that no struct field consumes.
+func WithStrict() Option {
+	return func(c *config) {
+		c.strict = true
+	}
+}
+
+// WithUnknownFieldsReport calls fn with the value and file keys no struct
+// field consumes, if there are any, so that lenient endpoints can log them.
+// Unlike WithStrict, it does not make Convert fail.
+func WithUnknownFieldsReport(fn func(ErrUnknownFields)) Option {
+	return func(c *config) {
+		c.reportUnknown = fn
+	}
+}
+
+// WithKeyNaming derives the form key of fields without a name in their
+// tag from their Go name with fn, e.g. SnakeCase, KebabCase, CamelCase or
+// a custom function. By default the Go name is used as it is. Names in form
+// tags always win.
+func WithKeyNaming(fn func(string) string) Option {
+	return func(c *config) {
+		c.keyNaming = fn
+	}
+}
+
+// WithCaseInsensitiveKeys matches form keys to field keys
+// case-insensitively. Exact matches take precedence.
+func WithCaseInsensitiveKeys() Option {
+	return func(c *config) {
+		c.caseInsensitive = true
+	}
+}
+
+// WithTagName reads the struct tag with the given name instead of "form".
+func WithTagName(name string) Option {
+	return func(c *config) {
+		c.tagName = name
+	}
+}
+
+// WithJSONFallback uses the name of the json tag for fields without a form
+// tag (or the tag set with WithTagName). Fields tagged `json:"-"` are
+// ignored; json tag options such as omitempty are not used.
+func WithJSONFallback() Option {
+	return func(c *config) {
+		c.jsonFallback = true
+	}
+}
+
+// WithDeprecatedAliasReport calls fn with the key of a field and the alias
+// it was sent under whenever an alias is used instead of the key, so that
+// the migration of clients away from old keys can be tracked.
+func WithDeprecatedAliasReport(fn func(key, alias string)) Option {
+	return func(c *config) {
+		c.reportAlias = fn
+	}
+}
+
+// WithZeroMissing sets every field that receives no value or file to its
+// zero value, so that a pooled or pre-populated struct keeps no stale
+// values. By default such fields are left untouched.
+func WithZeroMissing() Option {
+	return func(c *config) {
+		c.zeroMissing = true
+	}
+}
+
+// WithSlicePolicy sets how slice fields that already hold elements are
+// decoded. The default is SliceReplace.
+func WithSlicePolicy(p SlicePolicy) Option {
+	return func(c *config) {
+		c.slicePolicy = p
+	}
+}
+
+// WithModifier registers a modifier for the mod struct tag under the given
+// name. It takes precedence over a built-in modifier of the same name.
+func WithModifier(name string, m Modifier) Option {
+	return func(c *config) {
+		if c.modifiers == nil {
+			c.modifiers = make(map[string]Modifier)
+		}
+		c.modifiers[name] = m
+	}
+}
+
+// WithNumberFormat sets the format of integer and float values, e.g.
+// NumberFormat{Decimal: ",", Group: "."} for "1.234,56". The number tag
+// options override it for a single field.
+func WithNumberFormat(nf NumberFormat) Option {
+	return func(c *config) {
+		c.numbers = nf
+	}
+}
+
+func lower(words []string) []string {
+	out := make([]string, len(words))
+	for i, w := range words {
+		out[i] = strings.ToLower(w)
+	}
+	return out
+}
@@ -0,0 +1,40 @@
+package m2s
+
+import (
+	"reflect"
+	"slices"
+)
+
+// Presence lists the fields Convert set, so that PATCH-style handlers can
+// tell a field that was not sent apart from one sent with its zero value.
+// Convert fills it into the struct fields of type Presence.
+type Presence struct {
+	Keys  []string // form keys of the fields that were set, in field order
+	Paths []string // Go paths of the fields that were set, in field order
+}
+
+var presenceType = reflect.TypeFor[Presence]()
+
+// Has reports whether the field with the given Go path was set.
+func (p Presence) Has(path string) bool {
+	return slices.Contains(p.Paths, path)
+}
+
+// HasKey reports whether the field with the given form key was set.
+func (p Presence) HasKey(key string) bool {
+	return slices.Contains(p.Keys, key)
+}
+
+func (p *Presence) add(f field) {
+	p.Keys = append(p.Keys, f.key)
+	p.Paths = append(p.Paths, f.path)
+}
+
+// setPresence stores presence in the fields of rv of type Presence.
+func setPresence(rv reflect.Value, fields []field, presence Presence) {
+	for _, f := range fields {
+		if f.typ == presenceType {
+			rv.Field(f.index).Set(reflect.ValueOf(presence))
+		}
+	}
+}
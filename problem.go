@@ -0,0 +1,107 @@
+package m2s
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+)
+
+// ProblemContentType is the media type of a Problem document.
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details document describing why a
+// multipart form could not be decoded.
+type Problem struct {
+	Type          string         `json:"type"`
+	Title         string         `json:"title"`
+	Status        int            `json:"status"`
+	Detail        string         `json:"detail,omitempty"`
+	Instance      string         `json:"instance,omitempty"`
+	InvalidParams []InvalidParam `json:"invalid-params,omitempty"`
+}
+
+// InvalidParam describes a single form key that could not be decoded.
+type InvalidParam struct {
+	Name   string `json:"name"`   // form key
+	Reason string `json:"reason"` // human-readable reason
+	Code   string `json:"code"`   // stable error code, e.g. "parse.int"
+}
+
+// NewProblem converts an error returned by m2s into a Problem. Field errors,
+// including those combined with errors.Join, are listed in InvalidParams.
+// Errors caused by a too large request body get the status
+// 413 Request Entity Too Large, all others 400 Bad Request.
+func NewProblem(err error) *Problem {
+	return NewLocalizedProblem(err, nil)
+}
+
+// NewLocalizedProblem is like NewProblem, but renders the detail and the
+// reasons of the invalid params with catalog.
+func NewLocalizedProblem(err error, catalog MessageCatalog) *Problem {
+	status := http.StatusBadRequest
+	if ErrorCode(err) == CodeFormTooLarge {
+		status = http.StatusRequestEntityTooLarge
+	}
+
+	p := &Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+	}
+
+	var details []string
+	for _, err := range flattenErrors(err) {
+		var kerr keyedError
+		if !errors.As(err, &kerr) {
+			details = append(details, Message(err, catalog))
+			continue
+		}
+
+		reason := errors.Unwrap(kerr).Error()
+		if catalog != nil {
+			reason = Message(kerr, catalog)
+		}
+
+		p.InvalidParams = append(p.InvalidParams, InvalidParam{
+			Name:   kerr.formKey(),
+			Reason: reason,
+			Code:   ErrorCode(kerr),
+		})
+	}
+
+	switch {
+	case len(details) > 0:
+		p.Detail = strings.Join(details, "\n")
+	case len(p.InvalidParams) > 0:
+		p.Detail = "the multipart form contains invalid fields"
+		if catalog != nil {
+			p.Detail = cmp.Or(catalog.Message(CodeInvalidFields, nil), p.Detail)
+		}
+	}
+
+	return p
+}
+
+// WriteProblem writes err as an application/problem+json response. It can
+// be passed to WithErrorHandler.
+func WriteProblem(w http.ResponseWriter, r *http.Request, err error) {
+	writeProblem(w, r, NewProblem(err))
+}
+
+// WriteLocalizedProblem is like WriteProblem, but renders the messages in
+// the language CatalogFor chooses for r.
+func WriteLocalizedProblem(w http.ResponseWriter, r *http.Request, err error) {
+	lang := negotiateLanguage(r)
+	w.Header().Set("Content-Language", lang)
+	writeProblem(w, r, NewLocalizedProblem(err, Catalogs[lang]))
+}
+
+func writeProblem(w http.ResponseWriter, r *http.Request, p *Problem) {
+	p.Instance = r.URL.Path
+
+	w.Header().Set("Content-Type", ProblemContentType)
+	w.WriteHeader(p.Status)
+	json.NewEncoder(w).Encode(p)
+}
@@ -0,0 +1,209 @@
+package m2s
+
+import (
+	"encoding/json"
+	"errors"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewProblem(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want *Problem
+	}{
+		{
+			name: "parse error",
+			err:  ErrParseFailed{Field: "Age", Key: "age", Code: CodeParseInt, Err: errors.New("invalid syntax")},
+			want: &Problem{
+				Type:   "about:blank",
+				Title:  "Bad Request",
+				Status: http.StatusBadRequest,
+				Detail: "the multipart form contains invalid fields",
+				InvalidParams: []InvalidParam{
+					{Name: "age", Reason: "invalid syntax", Code: CodeParseInt},
+				},
+			},
+		},
+		{
+			name: "joined errors",
+			err: errors.Join(
+				ErrParseFailed{Field: "Age", Key: "age", Code: CodeParseInt, Err: errors.New("invalid syntax")},
+				ErrParseFailed{Field: "Active", Key: "active", Code: CodeParseBool, Err: errors.New("invalid syntax")},
+			),
+			want: &Problem{
+				Type:   "about:blank",
+				Title:  "Bad Request",
+				Status: http.StatusBadRequest,
+				Detail: "the multipart form contains invalid fields",
+				InvalidParams: []InvalidParam{
+					{Name: "age", Reason: "invalid syntax", Code: CodeParseInt},
+					{Name: "active", Reason: "invalid syntax", Code: CodeParseBool},
+				},
+			},
+		},
+		{
+			name: "other error",
+			err:  ErrValueMustBeStruct,
+			want: &Problem{
+				Type:   "about:blank",
+				Title:  "Bad Request",
+				Status: http.StatusBadRequest,
+				Detail: "value must be a struct",
+			},
+		},
+		{
+			name: "message too large",
+			err:  multipart.ErrMessageTooLarge,
+			want: &Problem{
+				Type:   "about:blank",
+				Title:  "Request Entity Too Large",
+				Status: http.StatusRequestEntityTooLarge,
+				Detail: "multipart: message too large",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewProblem(tt.err)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewProblem() got = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewProblemFromConvert(t *testing.T) {
+	mpf := &multipart.Form{
+		Value: map[string][]string{
+			"age":    {"abc"},
+			"name":   {"John"},
+			"active": {"maybe"},
+		},
+	}
+
+	var v struct {
+		Age    int    `form:"age"`
+		Name   string `form:"name"`
+		Active bool   `form:"active"`
+	}
+	err := Convert(mpf, &v)
+
+	var names []string
+	for _, p := range NewProblem(err).InvalidParams {
+		names = append(names, p.Name)
+	}
+	if want := []string{"age", "active"}; !reflect.DeepEqual(names, want) {
+		t.Errorf("NewProblem() invalid params got = %v, want %v", names, want)
+	}
+}
+
+func TestWriteProblem(t *testing.T) {
+	type Body struct {
+		Age int `form:"age"`
+	}
+
+	h := Handler(func(w http.ResponseWriter, r *http.Request, body Body) {}, WithErrorHandler(WriteProblem))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, newMultipartRequest(t, map[string]string{"age": "invalid"}, nil))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status got = %d, want %d", rec.Code, http.StatusBadRequest)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != ProblemContentType {
+		t.Errorf("content type got = %q", ct)
+	}
+
+	var got map[string]any
+	err := json.NewDecoder(rec.Body).Decode(&got)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]any{
+		"type":     "about:blank",
+		"title":    "Bad Request",
+		"status":   float64(http.StatusBadRequest),
+		"detail":   "the multipart form contains invalid fields",
+		"instance": "/",
+		"invalid-params": []any{
+			map[string]any{
+				"name":   "age",
+				"reason": `strconv.ParseInt: parsing "invalid": invalid syntax`,
+				"code":   CodeParseInt,
+			},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WriteProblem() got = %v, want %v", got, want)
+	}
+}
+
+func TestWriteLocalizedProblem(t *testing.T) {
+	type Body struct {
+		Age int `form:"age"`
+	}
+
+	h := Handler(func(w http.ResponseWriter, r *http.Request, body Body) {}, WithErrorHandler(WriteLocalizedProblem))
+
+	r := newMultipartRequest(t, map[string]string{"age": "invalid"}, nil)
+	r.Header.Set("Accept-Language", "tr-TR,tr;q=0.9,en;q=0.8")
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, r)
+
+	if lang := rec.Header().Get("Content-Language"); lang != "tr" {
+		t.Errorf("content language got = %q", lang)
+	}
+
+	var got Problem
+	err := json.NewDecoder(rec.Body).Decode(&got)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Problem{
+		Type:     "about:blank",
+		Title:    "Bad Request",
+		Status:   http.StatusBadRequest,
+		Detail:   "multipart form geçersiz alanlar içeriyor",
+		Instance: "/",
+		InvalidParams: []InvalidParam{
+			{Name: "age", Reason: "age bir tam sayı olmalıdır", Code: CodeParseInt},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("WriteLocalizedProblem() got = %+v, want %+v", got, want)
+	}
+}
+
+func TestNewProblemRedacted(t *testing.T) {
+	mpf := &multipart.Form{
+		Value: map[string][]string{
+			"pin": {"secret-1234"},
+		},
+	}
+
+	var v struct {
+		Pin int `form:"pin,redact"`
+	}
+	err := Convert(mpf, &v)
+	if err == nil || strings.Contains(err.Error(), "secret-1234") {
+		t.Fatalf("Convert() error = %v, want redacted error", err)
+	}
+
+	b, err := json.Marshal(NewProblem(err))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(b), "secret-1234") {
+		t.Errorf("NewProblem() got = %s, want no raw value", b)
+	}
+}
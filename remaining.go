@@ -0,0 +1,76 @@
+package m2s
+
+import (
+	"mime/multipart"
+	"reflect"
+	"slices"
+)
+
+var (
+	remainingValuesType = reflect.TypeFor[[]string]()
+	remainingFilesType  = reflect.TypeFor[[]*multipart.FileHeader]()
+)
+
+// remainingElem returns []string for a field that collects the remaining
+// values and []*multipart.FileHeader for one that collects the remaining
+// files, or nil if rt cannot hold either. The map key must be a string.
+func remainingElem(rt reflect.Type) reflect.Type {
+	if rt.Kind() != reflect.Map || rt.Key().Kind() != reflect.String {
+		return nil
+	}
+	switch rt.Elem() {
+	case remainingValuesType, remainingFilesType:
+		return rt.Elem()
+	default:
+		return nil
+	}
+}
+
+// isRemaining reports whether f is a catch-all field, tagged with the
+// remaining option.
+func isRemaining(f field) bool {
+	return f.opts.Contains("remaining")
+}
+
+// setRemaining collects the unknown keys of u into the catch-all fields of
+// rv and removes the keys they consume from u.
+func setRemaining(mpf *multipart.Form, rv reflect.Value, fields []field, u *ErrUnknownFields, presence *Presence) {
+	var values, files bool
+	for _, f := range fields {
+		if !isRemaining(f) {
+			continue
+		}
+		fieldValue := rv.Field(f.index)
+		switch remainingElem(f.typ) {
+		case remainingValuesType:
+			for _, key := range u.Values {
+				setMapIndex(fieldValue, key, reflect.ValueOf(slices.Clone(mpf.Value[key])))
+			}
+			if len(u.Values) > 0 {
+				presence.add(f)
+			}
+			values = true
+		case remainingFilesType:
+			for _, key := range u.Files {
+				setMapIndex(fieldValue, key, reflect.ValueOf(slices.Clone(mpf.File[key])))
+			}
+			if len(u.Files) > 0 {
+				presence.add(f)
+			}
+			files = true
+		}
+	}
+	if values {
+		u.Values = nil
+	}
+	if files {
+		u.Files = nil
+	}
+}
+
+func setMapIndex(m reflect.Value, key string, elem reflect.Value) {
+	if m.IsNil() {
+		m.Set(reflect.MakeMap(m.Type()))
+	}
+	m.SetMapIndex(reflect.ValueOf(key).Convert(m.Type().Key()), elem)
+}
@@ -0,0 +1,66 @@
+package m2s
+
+import "reflect"
+
+// SlicePolicy tells how Convert decodes a slice field that already holds
+// elements, e.g. in a pooled or pre-populated struct.
+type SlicePolicy uint
+
+const (
+	// SliceReplace replaces the elements of the field with the decoded
+	// ones. This is the default.
+	SliceReplace SlicePolicy = iota
+	// SliceAppend appends the decoded elements to those of the field.
+	SliceAppend
+)
+
+// resetField sets a field that received no value or file to its zero
+// value if c zeroes missing fields.
+func (c *config) resetField(fieldValue reflect.Value) {
+	if c.zeroMissing {
+		fieldValue.SetZero()
+	}
+}
+
+// previousElems returns a copy of the elements fieldValue holds before it
+// is decoded, if they are appended to under SliceAppend, or an invalid
+// Value. The copy is needed since decoding may reuse the backing array.
+func (c *config) previousElems(fieldValue reflect.Value) reflect.Value {
+	if c.slicePolicy != SliceAppend {
+		return reflect.Value{}
+	}
+	list := sliceOf(fieldValue)
+	if !list.IsValid() || list.Len() == 0 {
+		return reflect.Value{}
+	}
+	return reflect.AppendSlice(reflect.MakeSlice(list.Type(), 0, list.Len()), list)
+}
+
+// appendElems prepends prev, as returned by previousElems, to the decoded
+// elements of fieldValue. A field decoded to nil, e.g. from "null" with
+// WithNullAsNil, stays nil.
+func appendElems(fieldValue, prev reflect.Value) {
+	if !prev.IsValid() {
+		return
+	}
+	list := sliceOf(fieldValue)
+	if !list.IsValid() || list.IsNil() {
+		return
+	}
+	list.Set(reflect.AppendSlice(prev, list))
+}
+
+// sliceOf returns the slice rv holds through any pointers, or an invalid
+// Value if rv is not a slice or one of the pointers is nil.
+func sliceOf(rv reflect.Value) reflect.Value {
+	for rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return reflect.Value{}
+		}
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Slice {
+		return reflect.Value{}
+	}
+	return rv
+}
@@ -0,0 +1,84 @@
+package m2s
+
+import (
+	"reflect"
+	"strings"
+)
+
+// splitValue splits formValue at the separator of the split tag option of
+// f, "space" meaning any run of whitespace. With the trim option, the
+// elements are trimmed of surrounding whitespace.
+func splitValue(f field, formValue string) []string {
+	if formValue == "" {
+		return nil
+	}
+
+	var elems []string
+	switch sep, _ := f.opts.Lookup("split"); sep {
+	case "space":
+		elems = strings.Fields(formValue)
+	default:
+		elems = strings.Split(formValue, sep)
+	}
+
+	if f.opts.Contains("trim") {
+		for i := range elems {
+			elems[i] = strings.TrimSpace(elems[i])
+		}
+	}
+	return elems
+}
+
+// joinValues is the inverse of splitValue.
+func joinValues(f field, elems []string) string {
+	sep, _ := f.opts.Lookup("split")
+	if sep == "space" {
+		sep = " "
+	}
+	return strings.Join(elems, sep)
+}
+
+// convertSplit converts each element of a delimited form value with the
+// rules of convertValue.
+func (c *config) convertSplit(fieldType reflect.Type, fieldValue reflect.Value, f field, formValue string) error {
+	elems := splitValue(f, formValue)
+	list := reflect.MakeSlice(fieldType, len(elems), len(elems))
+	for i, elem := range elems {
+		err := c.convertValue(fieldType.Elem(), list.Index(i), f.elem(i), elem)
+		if err != nil {
+			return err
+		}
+	}
+	fieldValue.Set(list)
+	return nil
+}
+
+// encodeSplit is the inverse of convertSplit.
+func (c *config) encodeSplit(f field, rv reflect.Value) (string, error) {
+	elems := make([]string, rv.Len())
+	for i := range elems {
+		s, err := c.encodeValue(f, rv.Index(i))
+		if err != nil {
+			return "", err
+		}
+		elems[i] = s
+	}
+	return joinValues(f, elems), nil
+}
+
+// isSplit reports whether f is a slice or array field with the split tag
+// option.
+func isSplit(f field) bool {
+	return f.opts.Contains("split") && isListType(f.typ)
+}
+
+// isListType reports whether rt is a slice or an array, or a pointer to
+// one.
+func isListType(rt reflect.Type) bool {
+	switch indirectType(rt).Kind() {
+	case reflect.Slice, reflect.Array:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,112 @@
+package m2s
+
+import (
+	"mime/multipart"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ErrUnknownFields is returned by Convert with WithStrict when the form has
+// keys that no struct field consumed, e.g. because of a typo in a client.
+// It is also passed to the function set with WithUnknownFieldsReport.
+type ErrUnknownFields struct {
+	Values []string // unused keys of mpf.Value, sorted
+	Files  []string // unused keys of mpf.File, sorted
+}
+
+func (e ErrUnknownFields) Error() string {
+	return "unknown form keys: " + strings.Join(e.keys(), ", ")
+}
+
+func (e ErrUnknownFields) ErrorCode() string {
+	return CodeUnknownFields
+}
+
+func (e ErrUnknownFields) ErrorParams() map[string]any {
+	return map[string]any{
+		"keys": strings.Join(e.keys(), ", "),
+	}
+}
+
+func (e ErrUnknownFields) keys() []string {
+	return append(slices.Clone(e.Values), e.Files...)
+}
+
+// unknownFields returns the keys of mpf that none of fields consumes.
+func unknownFields(mpf *multipart.Form, fields []field) ErrUnknownFields {
+	var u ErrUnknownFields
+	for key := range mpf.Value {
+		if !slices.ContainsFunc(fields, func(f field) bool { return f.kind == FieldValue && f.usesKey(key) }) {
+			u.Values = append(u.Values, key)
+		}
+	}
+	for key := range mpf.File {
+		if !slices.ContainsFunc(fields, func(f field) bool { return f.kind != FieldValue && f.usesKey(key) }) {
+			u.Files = append(u.Files, key)
+		}
+	}
+	slices.Sort(u.Values)
+	slices.Sort(u.Files)
+	return u
+}
+
+// usesKey reports whether f is decoded from key: its own key, one of its
+// aliases or, for arrays, an indexed key such as rgb[0]. Catch-all fields
+// use no key.
+func (f field) usesKey(key string) bool {
+	if isRemaining(f) {
+		return false
+	}
+	return slices.ContainsFunc(f.keys(), func(name string) bool {
+		return matchesKey(name, key, isArrayType(f.withoutOptional().typ))
+	})
+}
+
+// keys returns the key of f followed by its aliases.
+func (f field) keys() []string {
+	return append([]string{f.key}, f.aliases...)
+}
+
+// matchesKey reports whether key is name or, for arrays, name followed by
+// an index.
+func matchesKey(name, key string, array bool) bool {
+	if key == name {
+		return true
+	}
+	if !array {
+		return false
+	}
+	index, ok := strings.CutPrefix(key, name+"[")
+	if !ok {
+		return false
+	}
+	index, ok = strings.CutSuffix(index, "]")
+	if !ok {
+		return false
+	}
+	_, err := strconv.Atoi(index)
+	return err == nil
+}
+
+// checkUnknownFields collects the unknown keys of mpf into the catch-all
+// fields of rv, reports the keys left and, in strict mode, returns them as
+// an error. Catch-all fields that receive keys are added to presence.
+func (c *config) checkUnknownFields(mpf *multipart.Form, rv reflect.Value, fields []field, presence *Presence) error {
+	if !c.strict && c.reportUnknown == nil && !slices.ContainsFunc(fields, isRemaining) {
+		return nil
+	}
+	u := unknownFields(mpf, fields)
+	setRemaining(mpf, rv, fields, &u, presence)
+	if len(u.Values) == 0 && len(u.Files) == 0 {
+		return nil
+	}
+	if c.reportUnknown != nil {
+		c.reportUnknown(u)
+	}
+	if c.strict {
+		return u
+	}
+	return nil
+}
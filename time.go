@@ -0,0 +1,77 @@
+package m2s
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ksckaan1/m2s/internal/tags"
+)
+
+var timeType = reflect.TypeFor[time.Time]()
+
+// usesTimeParser reports whether f is converted by parseTime instead of
+// time.Time's UnmarshalText, which only accepts RFC 3339.
+func (c *config) usesTimeParser(f field) bool {
+	if len(c.timeLayouts) > 0 {
+		return true
+	}
+	for _, opt := range tags.TimeOptions {
+		if f.opts.Contains(opt) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseTime parses formValue as configured by the time options of f: as
+// Unix seconds or milliseconds, or with the first matching layout.
+func (c *config) parseTime(f field, formValue string) (time.Time, error) {
+	switch {
+	case f.opts.Contains("unix"):
+		sec, err := strconv.ParseInt(formValue, 10, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.Unix(sec, 0).In(c.location), nil
+	case f.opts.Contains("unixmilli"):
+		msec, err := strconv.ParseInt(formValue, 10, 64)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return time.UnixMilli(msec).In(c.location), nil
+	}
+
+	var firstErr error
+	for _, layout := range c.layouts(f) {
+		t, err := time.ParseInLocation(layout, formValue, c.location)
+		if err == nil {
+			return t, nil
+		}
+		if firstErr == nil {
+			firstErr = err
+		}
+	}
+	return time.Time{}, firstErr
+}
+
+// formatTime is the inverse of parseTime.
+func (c *config) formatTime(f field, t time.Time) string {
+	switch {
+	case f.opts.Contains("unix"):
+		return strconv.FormatInt(t.Unix(), 10)
+	case f.opts.Contains("unixmilli"):
+		return strconv.FormatInt(t.UnixMilli(), 10)
+	}
+	return t.In(c.location).Format(c.layouts(f)[0])
+}
+
+// layouts returns the layouts of the layout tag option of f, or RFC 3339
+// followed by the layouts set with WithTimeLayouts.
+func (c *config) layouts(f field) []string {
+	if layout, ok := f.opts.Lookup("layout"); ok {
+		return strings.Split(layout, "|")
+	}
+	return append([]string{time.RFC3339}, c.timeLayouts...)
+}